@@ -1,17 +1,34 @@
 package cmd
 
 import (
+	"mantle/pkg/codec"
 	"mantle/pkg/initialize"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	opts   codec.Options
+	output string
+)
+
 var RootCmd = &cobra.Command{
 	Use:           "pulsar",
 	Short:         "deploys and manages apache pulsar",
 	SilenceErrors: true,
 	SilenceUsage:  true,
 	RunE: func(_ *cobra.Command, args []string) error {
-		return initialize.MantleInit()
+		format, err := codec.ParseFormat(output)
+		if err != nil {
+			return err
+		}
+		opts.Format = format
+		return initialize.MantleInit(opts)
 	},
 }
+
+func init() {
+	RootCmd.Flags().StringVarP(&opts.Filename, "filename", "f", "", "file to convert, stdin is read when not set")
+	RootCmd.Flags().StringVarP(&output, "output", "o", string(codec.FormatYAML), "output format: yaml, json or pretty-json")
+	RootCmd.Flags().BoolVar(&opts.WrapList, "wrap-list", false, "wrap converted kubernetes objects in a single List")
+}
@@ -0,0 +1,19 @@
+package converterutils
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ConvertKubeObject copies in into out through their unstructured form.
+// Versions of a kubernetes type that only differ in a few fields, e.g.
+// apps/v1beta1 and apps/v1 deployments, are converted this way so that
+// only the latest version needs a full conversion.  Fields that only
+// exist in in are dropped.
+func ConvertKubeObject(in, out interface{}) error {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(in)
+	if err != nil {
+		return err
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj, out)
+}
@@ -0,0 +1,126 @@
+package converterutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 6, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+var cronDescriptors = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+const cronEvery = "@every "
+
+// ValidateCronSchedule checks that schedule is a cron expression the cron
+// job controller accepts: either five fields (minute, hour, day of month,
+// month and day of week), a descriptor such as "@daily", or
+// "@every <duration>"
+func ValidateCronSchedule(schedule string) error {
+	if strings.HasPrefix(schedule, cronEvery) {
+		_, err := time.ParseDuration(strings.TrimPrefix(schedule, cronEvery))
+		if err != nil {
+			return serrors.InvalidValueErrorf(schedule, "invalid duration: %s", err)
+		}
+		return nil
+	}
+	if strings.HasPrefix(schedule, "@") {
+		if !cronDescriptors[schedule] {
+			return serrors.InvalidValueErrorf(schedule, "unrecognized cron descriptor")
+		}
+		return nil
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return serrors.InvalidValueErrorf(schedule, "expected %d fields in cron schedule, found %d", len(cronFields), len(fields))
+	}
+
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			err := cronFields[i].validate(item)
+			if err != nil {
+				return serrors.InvalidValueErrorf(schedule, "invalid %s (%s): %s", cronFields[i].name, item, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks one item of a field's list, which is "*", "?", a value
+// or a range "a-b", optionally followed by a step "/n"
+func (f cronField) validate(item string) error {
+	rangeStr := item
+	if i := strings.Index(item, "/"); i >= 0 {
+		rangeStr = item[:i]
+		step, err := strconv.Atoi(item[i+1:])
+		if err != nil || step <= 0 {
+			return fmt.Errorf("step should be a positive integer")
+		}
+	}
+
+	if rangeStr == "*" || rangeStr == "?" {
+		return nil
+	}
+
+	bounds := strings.Split(rangeStr, "-")
+	if len(bounds) > 2 {
+		return fmt.Errorf("expected a value or a range")
+	}
+
+	values := make([]int, len(bounds))
+	for i, bound := range bounds {
+		val, err := f.parseValue(bound)
+		if err != nil {
+			return err
+		}
+		values[i] = val
+	}
+	if len(values) == 2 && values[0] > values[1] {
+		return fmt.Errorf("range start is after its end")
+	}
+
+	return nil
+}
+
+func (f cronField) parseValue(str string) (int, error) {
+	for i, name := range f.names {
+		if strings.ToLower(str) == name {
+			return f.min + i, nil
+		}
+	}
+
+	val, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, fmt.Errorf("expected a number")
+	}
+	if val < f.min || val > f.max {
+		return 0, fmt.Errorf("out of range %d-%d", f.min, f.max)
+	}
+
+	return val, nil
+}
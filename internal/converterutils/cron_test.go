@@ -0,0 +1,34 @@
+package converterutils
+
+import (
+	"testing"
+)
+
+func TestValidateCronSchedule(t *testing.T) {
+	testcases := []struct {
+		schedule string
+		pass     bool
+	}{
+		{schedule: "*/5 * * * *", pass: true},
+		{schedule: "0 3 * * mon-fri", pass: true},
+		{schedule: "15,45 0-6/2 1 JAN,jul ?", pass: true},
+		{schedule: "@daily", pass: true},
+		{schedule: "@every 1h30m", pass: true},
+		{schedule: "* * * *", pass: false},
+		{schedule: "60 * * * *", pass: false},
+		{schedule: "0 0 0 * *", pass: false},
+		{schedule: "0 0 * * 7", pass: false},
+		{schedule: "*/0 * * * *", pass: false},
+		{schedule: "5-1 * * * *", pass: false},
+		{schedule: "0 0 * foo *", pass: false},
+		{schedule: "@fortnightly", pass: false},
+		{schedule: "@every soon", pass: false},
+	}
+
+	for _, tc := range testcases {
+		err := ValidateCronSchedule(tc.schedule)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.schedule, err)
+		}
+	}
+}
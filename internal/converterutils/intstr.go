@@ -0,0 +1,25 @@
+package converterutils
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ParseIntOrStringPtr parses a number, e.g. "1", or a percentage, e.g.
+// "25%".  An empty string is nil.
+func ParseIntOrStringPtr(str string) *intstr.IntOrString {
+	if len(str) == 0 {
+		return nil
+	}
+
+	val := intstr.Parse(str)
+	return &val
+}
+
+// FormatIntOrStringPtr is the inverse of ParseIntOrStringPtr
+func FormatIntOrStringPtr(val *intstr.IntOrString) string {
+	if val == nil {
+		return ""
+	}
+
+	return val.String()
+}
@@ -0,0 +1,18 @@
+package marshal
+
+import (
+	"strconv"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+// ParsePort parses the port number in a port string, e.g. the "8080" in
+// "http:80->8080/tcp"
+func ParsePort(str string) (int32, error) {
+	port, err := strconv.ParseInt(str, 10, 32)
+	if err != nil || port > 65535 {
+		return 0, serrors.InvalidValueErrorf(str, "expected a port number")
+	}
+
+	return int32(port), nil
+}
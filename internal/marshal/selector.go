@@ -0,0 +1,84 @@
+package marshal
+
+import (
+	"strconv"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+const (
+	SelectorSeparator = ":"
+	selectorQuote     = '"'
+)
+
+// JoinSelector joins the segments of a volume string, e.g.
+// "nfs:server:/export".  Segments that contain the separator, e.g. iSCSI
+// IQNs or IPv6 addresses, or that start with a quote are written as Go
+// quoted strings so that SplitSelector can read them back:
+//
+//	nfs:"fd00::1":/export
+func JoinSelector(segments []string) string {
+	quoted := make([]string, len(segments))
+	for i, segment := range segments {
+		if strings.Contains(segment, SelectorSeparator) || strings.HasPrefix(segment, string(selectorQuote)) {
+			segment = strconv.Quote(segment)
+		}
+		quoted[i] = segment
+	}
+
+	return strings.Join(quoted, SelectorSeparator)
+}
+
+// SplitSelector splits a volume string written by JoinSelector into its
+// segments.  Unquoted segments end at the next separator, quoted segments
+// end at the closing quote, which must be followed by a separator or the
+// end of the string.
+func SplitSelector(str string) ([]string, error) {
+	segments := []string{}
+	for {
+		if len(str) == 0 || str[0] != selectorQuote {
+			i := strings.Index(str, SelectorSeparator)
+			if i < 0 {
+				return append(segments, str), nil
+			}
+			segments = append(segments, str[:i])
+			str = str[i+len(SelectorSeparator):]
+			continue
+		}
+
+		end := closingQuote(str)
+		if end < 0 {
+			return nil, serrors.InvalidValueErrorf(str, "unterminated quoted selector segment")
+		}
+		segment, err := strconv.Unquote(str[:end+1])
+		if err != nil {
+			return nil, serrors.InvalidValueErrorf(str[:end+1], "invalid quoted selector segment: %s", err)
+		}
+		segments = append(segments, segment)
+
+		str = str[end+1:]
+		if len(str) == 0 {
+			return segments, nil
+		}
+		if !strings.HasPrefix(str, SelectorSeparator) {
+			return nil, serrors.InvalidValueErrorf(str, "expected %s after quoted selector segment", SelectorSeparator)
+		}
+		str = str[len(SelectorSeparator):]
+	}
+}
+
+// closingQuote returns the index of the quote that ends the quoted
+// string at the start of str, or -1 if there is none
+func closingQuote(str string) int {
+	for i := 1; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case selectorQuote:
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,57 @@
+package marshal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectorRoundTrip(t *testing.T) {
+	testcases := []struct {
+		segments []string
+		joined   string
+	}{
+		{
+			segments: []string{"pvc", "claim", "ro"},
+			joined:   "pvc:claim:ro",
+		},
+		{
+			segments: []string{"nfs", "fd00::1", "/export"},
+			joined:   `nfs:"fd00::1":/export`,
+		},
+		{
+			segments: []string{"git", "https://example.com/repo.git"},
+			joined:   `git:"https://example.com/repo.git"`,
+		},
+		{
+			segments: []string{"host_path", `"quoted"`, ""},
+			joined:   `host_path:"\"quoted\"":`,
+		},
+	}
+
+	for _, tc := range testcases {
+		joined := JoinSelector(tc.segments)
+		if joined != tc.joined {
+			t.Errorf("%v: expected %s, got %s", tc.segments, tc.joined, joined)
+		}
+
+		segments, err := SplitSelector(joined)
+		if err != nil {
+			t.Errorf("%s: split failed: %v", joined, err)
+		}
+		if !reflect.DeepEqual(segments, tc.segments) {
+			t.Errorf("%s: expected %v, got %v", joined, tc.segments, segments)
+		}
+	}
+}
+
+func TestSplitSelectorErrors(t *testing.T) {
+	for _, str := range []string{
+		`nfs:"fd00::1`,
+		`nfs:"fd00::1"/export`,
+		`nfs:"\q"`,
+	} {
+		if _, err := SplitSelector(str); err == nil {
+			t.Errorf("%s: expected an error", str)
+		}
+	}
+}
@@ -13,7 +13,7 @@ import (
 
 type CephFSVolume struct {
 	Monitors        []string               `json:"monitors"`
-	Path            string                 `json:"path, omitempty"`
+	Path            string                 `json:"path,omitempty"`
 	User            string                 `json:"user,omitempty"`
 	SecretFileOrRef *CephFSSecretFileOrRef `json:"secret,omitempty"`
 	ReadOnly        bool                   `json:"ro,omitempty"`
@@ -8,6 +8,8 @@ import (
 	"mantle/internal/pkg/core/pod/volume/filemode"
 	"mantle/internal/pkg/core/pod/volume/keyandmode"
 
+	serrors "github.com/koki/structurederrors"
+
 	"k8s.io/api/core/v1"
 )
 
@@ -27,6 +29,10 @@ func NewConfigMapVolumeFromKubeConfigMapVolumeSource(obj interface{}) (*ConfigMa
 }
 
 func fromKubeConfigMapVolumeSourceV1(vol *v1.ConfigMapVolumeSource) (*ConfigMapVolume, error) {
+	if len(vol.Name) == 0 {
+		return nil, serrors.InvalidInstanceErrorf(vol, "config name is required")
+	}
+
 	return &ConfigMapVolume{
 		Name:        converterutils.FromKubeLocalObjectReferenceV1(&vol.LocalObjectReference),
 		Items:       keyandmode.NewKeyToPathFromKubeKeyToPathV1(vol.Items),
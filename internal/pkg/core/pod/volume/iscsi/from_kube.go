@@ -10,15 +10,15 @@ import (
 	"k8s.io/api/core/v1"
 )
 
-// NewAwsEBSVolumeFromKubeISCSIVolumeSource will create a new
-// AwsEBSVolume object with the data from a provided kubernetes
+// NewISCSIVolumeFromKubeISCSIVolumeSource will create a new
+// ISCSIVolume object with the data from a provided kubernetes
 // ISCSIVolumeSource object
-func NewAwsEBSVolumeFromKubeISCSIVolumeSource(obj interface{}) (*ISCSIVolume, error) {
+func NewISCSIVolumeFromKubeISCSIVolumeSource(obj interface{}) (*ISCSIVolume, error) {
 	switch reflect.TypeOf(obj) {
 	case reflect.TypeOf(v1.ISCSIVolumeSource{}):
 		o := obj.(v1.ISCSIVolumeSource)
 		return fromKubeISCSIVolumeSourceV1(&o)
-	case reflect.TypeOf(&v1.AWSElasticBlockStoreVolumeSource{}):
+	case reflect.TypeOf(&v1.ISCSIVolumeSource{}):
 		return fromKubeISCSIVolumeSourceV1(obj.(*v1.ISCSIVolumeSource))
 	default:
 		return nil, fmt.Errorf("unknown ISCSIVolumeSource version: %s", reflect.TypeOf(obj))
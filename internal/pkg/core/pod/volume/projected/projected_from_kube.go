@@ -25,10 +25,6 @@ func NewProjectedVolumeFromKubeProjectedVolumeSource(obj interface{}) (*Projecte
 }
 
 func fromKubeProjectedVolumeSourceV1(vol *v1.ProjectedVolumeSource) (*ProjectedVolume, error) {
-	if len(vol.Sources) == 0 {
-		return nil, nil
-	}
-
 	sources := []VolumeProjection{}
 	for _, kubeSource := range vol.Sources {
 		source, err := NewVolumeProjectionFromKubeVolumeProjection(kubeSource)
@@ -0,0 +1,93 @@
+package selector
+
+import (
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LabelSelector selects objects by their labels.  It is written in the
+// label selector syntax of kubectl, e.g. "app=web,tier in (db,cache),!canary".
+// A dictionary of labels is accepted as well and matches those labels
+// exactly.
+type LabelSelector struct {
+	MatchLabels      map[string]string
+	MatchExpressions []metav1.LabelSelectorRequirement
+}
+
+func (s *LabelSelector) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		return s.Unmarshal(str)
+	}
+
+	labels := map[string]string{}
+	err = json.Unmarshal(data, &labels)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected either a label selector string or a dictionary of labels")
+	}
+
+	s.MatchLabels = labels
+	return nil
+}
+
+func (s *LabelSelector) Unmarshal(str string) error {
+	kubeSelector, err := metav1.ParseToLabelSelector(str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(str, "%s", err)
+	}
+
+	*s = *NewLabelSelectorFromKubeLabelSelectorV1(kubeSelector)
+	return nil
+}
+
+func (s LabelSelector) MarshalJSON() ([]byte, error) {
+	str, err := s.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(str)
+}
+
+func (s LabelSelector) Marshal() (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(s.ToKubeLabelSelectorV1())
+	if err != nil {
+		return "", serrors.InvalidInstanceErrorf(s, "%s", err)
+	}
+
+	return selector.String(), nil
+}
+
+// NewLabelSelectorFromKubeLabelSelectorV1 will create a new LabelSelector
+// object with the data from a provided kubernetes label selector
+func NewLabelSelectorFromKubeLabelSelectorV1(kubeSelector *metav1.LabelSelector) *LabelSelector {
+	if kubeSelector == nil {
+		return nil
+	}
+
+	s := &LabelSelector{}
+	if len(kubeSelector.MatchLabels) > 0 {
+		s.MatchLabels = kubeSelector.MatchLabels
+	}
+	if len(kubeSelector.MatchExpressions) > 0 {
+		s.MatchExpressions = kubeSelector.MatchExpressions
+	}
+
+	return s
+}
+
+// ToKubeLabelSelectorV1 will return a kubernetes label selector with the
+// data from the object
+func (s *LabelSelector) ToKubeLabelSelectorV1() *metav1.LabelSelector {
+	if s == nil {
+		return nil
+	}
+
+	return &metav1.LabelSelector{
+		MatchLabels:      s.MatchLabels,
+		MatchExpressions: s.MatchExpressions,
+	}
+}
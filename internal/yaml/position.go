@@ -0,0 +1,324 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// Position is a location in a YAML source.  Line and Column start at 1,
+// a zero Column means the column is unknown.
+type Position struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+func (p Position) String() string {
+	filename := p.Filename
+	if len(filename) == 0 {
+		filename = "<input>"
+	}
+	if p.Column == 0 {
+		return fmt.Sprintf("%s:%d", filename, p.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", filename, p.Line, p.Column)
+}
+
+// PositionError is an error annotated with the position of the YAML node
+// that caused it and an excerpt of the source around that node
+type PositionError struct {
+	Position
+	Excerpt string
+	Err     error
+}
+
+func (e *PositionError) Error() string {
+	if len(e.Excerpt) == 0 {
+		return fmt.Sprintf("%s: %s", e.Position, e.Err)
+	}
+	return fmt.Sprintf("%s: %s\n%s", e.Position, e.Err, e.Excerpt)
+}
+
+// Source is a YAML document together with where it was read from, so
+// that errors can point back at the offending node
+type Source struct {
+	Filename string
+	// LineOffset is the number of lines before the document in the file
+	LineOffset int
+	Data       []byte
+}
+
+var syntaxErrorRegexp = regexp.MustCompile(`yaml: line ([0-9]+): `)
+
+// Annotate returns err as a PositionError pointing at the node err refers
+// to.  The node is found by following the JSON path recorded in err, see
+// ErrorPath, and the path is joined into a single context, see
+// JoinErrorPath.  Errors without a path point at the start of the
+// document, and errors that already carry a position are returned
+// unchanged.
+func (s *Source) Annotate(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*PositionError); ok {
+		return err
+	}
+	err = JoinErrorPath(err)
+
+	// Syntax errors carry a line relative to the document, which is
+	// replaced by the line in the file.
+	if matches := syntaxErrorRegexp.FindStringSubmatch(err.Error()); len(matches) > 0 {
+		line, _ := strconv.Atoi(matches[1])
+		pos := Position{Filename: s.Filename, Line: s.LineOffset + line}
+		return &PositionError{
+			Position: pos,
+			Excerpt:  s.Excerpt(pos),
+			Err:      errors.New(syntaxErrorRegexp.ReplaceAllString(err.Error(), "yaml: ")),
+		}
+	}
+
+	pos := s.Locate(ErrorPath(err))
+	return &PositionError{
+		Position: pos,
+		Excerpt:  s.Excerpt(pos),
+		Err:      err,
+	}
+}
+
+// UnmarshalSource is like Unmarshal, but errors are returned as a
+// *PositionError pointing at the offending node of s
+func UnmarshalSource(s *Source, o interface{}) error {
+	vo := reflect.ValueOf(o)
+	j, err := yamlToJSON(s.Data, &vo)
+	if err != nil {
+		return s.Annotate(serrors.ContextualizeErrorf(err, "error converting YAML to JSON"))
+	}
+
+	err = json.Unmarshal(j, o)
+	if err != nil {
+		return s.Annotate(serrors.ContextualizeErrorf(err, "error unmarshaling JSON"))
+	}
+
+	return nil
+}
+
+// Locate returns the position of the node at path.  If the path can't be
+// followed to its end, the position of the deepest node found is
+// returned instead.
+func (s *Source) Locate(path []string) Position {
+	pos := Position{
+		Filename: s.Filename,
+		Line:     s.LineOffset + 1,
+		Column:   1,
+	}
+
+	root := &lineNode{}
+	if err := yaml.Unmarshal(s.Data, root); err != nil || root.line == 0 {
+		return pos
+	}
+
+	node, key := root, ""
+	for len(path) > 0 {
+		child, consumed := node.child(path)
+		if child == nil {
+			break
+		}
+		if node.mapping != nil {
+			key = strings.Join(path[:consumed], ".")
+		} else {
+			key = ""
+		}
+		node = child
+		path = path[consumed:]
+	}
+
+	lines := strings.Split(string(s.Data), "\n")
+	pos.Line = s.LineOffset + node.line
+	pos.Column = node.column(lines[node.line-1], key)
+	return pos
+}
+
+// lineNode is a YAML node along with the line it starts on.  yaml.v2
+// doesn't expose the line of a node, but reports it in type errors, so
+// each node provokes one to find its line.
+type lineNode struct {
+	line     int
+	mapping  map[interface{}]*lineNode
+	sequence []*lineNode
+}
+
+var typeErrorRegexp = regexp.MustCompile(`^line ([0-9]+): `)
+
+func (n *lineNode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// No node can be unmarshalled into a channel
+	var c chan struct{}
+	if typeErr, ok := unmarshal(&c).(*yaml.TypeError); ok && len(typeErr.Errors) > 0 {
+		if matches := typeErrorRegexp.FindStringSubmatch(typeErr.Errors[0]); len(matches) > 0 {
+			n.line, _ = strconv.Atoi(matches[1])
+		}
+	}
+
+	if err := unmarshal(&n.sequence); err == nil {
+		return nil
+	}
+	mapping := map[interface{}]*lineNode{}
+	if err := unmarshal(&mapping); err == nil {
+		n.mapping = mapping
+	}
+	return nil
+}
+
+// child returns the child of n named by the start of path, along with the
+// number of path segments it consumed.  Map keys may contain dots, so a
+// key that is not found is retried joined with the segments following it.
+func (n *lineNode) child(path []string) (*lineNode, int) {
+	if n.mapping != nil {
+		for count := 1; count <= len(path); count++ {
+			key := strings.Join(path[:count], ".")
+			for k, child := range n.mapping {
+				if fmt.Sprint(k) == key && child != nil && child.line > 0 {
+					return child, count
+				}
+			}
+		}
+		return nil, 0
+	}
+
+	i, err := strconv.Atoi(path[0])
+	if err == nil && i >= 0 && i < len(n.sequence) && n.sequence[i] != nil && n.sequence[i].line > 0 {
+		return n.sequence[i], 1
+	}
+	return nil, 0
+}
+
+// column guesses the column that n starts at on line, since type errors
+// only report lines.  A scalar or flow collection that follows its key
+// starts after the key, other nodes start at the first character that
+// isn't indentation or a sequence entry marker.
+func (n *lineNode) column(line, key string) int {
+	if len(key) > 0 {
+		for _, quoted := range []string{key, `"` + key + `"`, `'` + key + `'`} {
+			i := strings.Index(line, quoted+":")
+			if i < 0 {
+				continue
+			}
+			value := strings.TrimLeft(line[i+len(quoted)+1:], " \t")
+			isScalar := n.mapping == nil && n.sequence == nil
+			if len(value) > 0 && (isScalar || strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[")) {
+				return len(line) - len(value) + 1
+			}
+		}
+	}
+
+	trimmed := strings.TrimLeft(line, " \t")
+	for strings.HasPrefix(trimmed, "- ") {
+		trimmed = strings.TrimLeft(trimmed[1:], " \t")
+	}
+	return len(line) - len(trimmed) + 1
+}
+
+// Excerpt returns the source line at pos and the line before it, with a
+// marker under the column of pos
+func (s *Source) Excerpt(pos Position) string {
+	lines := strings.Split(string(s.Data), "\n")
+	line := pos.Line - s.LineOffset
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	width := len(strconv.Itoa(pos.Line))
+	excerpt := []string{}
+	if line > 1 {
+		excerpt = append(excerpt, fmt.Sprintf("%*d | %s", width, pos.Line-1, lines[line-2]))
+	}
+	excerpt = append(excerpt, fmt.Sprintf("%*d | %s", width, pos.Line, lines[line-1]))
+	if pos.Column > 0 {
+		excerpt = append(excerpt, fmt.Sprintf("%*s | %s^", width, "", strings.Repeat(" ", pos.Column-1)))
+	}
+	return strings.Join(excerpt, "\n")
+}
+
+// ErrorPath returns the JSON path, outermost segment first, of the value
+// that err refers to.  The json package records the path while
+// unmarshalling, and it is kept as "$.a.b" context when errors are
+// contextualized.
+func ErrorPath(err error) []string {
+	path := []string{}
+	for {
+		switch e := err.(type) {
+		case *PositionError:
+			err = e.Err
+		case *serrors.ErrorWithContext:
+			for _, context := range serrors.ReversedStringsList(e.Context) {
+				path = append(path, parseJSONPath(context)...)
+			}
+			err = e.BaseError
+		case *json.ErrorWithPath:
+			path = append(path, serrors.ReversedStringsList(e.Path)...)
+			err = e.BaseError
+		default:
+			return path
+		}
+	}
+}
+
+// JoinErrorPath returns err with the JSON paths recorded at each level of
+// it joined into a single "$.a.b.c" context, so that the error names one
+// path from the root of the document.  Other context follows the path.
+func JoinErrorPath(err error) error {
+	path := []string{}
+	context := []string{}
+	base := err
+	for {
+		switch e := base.(type) {
+		case *serrors.ErrorWithContext:
+			for _, c := range serrors.ReversedStringsList(e.Context) {
+				if strings.HasPrefix(c, "$.") {
+					path = append(path, parseJSONPath(c)...)
+				} else {
+					context = append(context, c)
+				}
+			}
+			base = e.BaseError
+			continue
+		case *json.ErrorWithPath:
+			path = append(path, serrors.ReversedStringsList(e.Path)...)
+			base = e.BaseError
+			continue
+		}
+		break
+	}
+
+	if len(path) == 0 {
+		return err
+	}
+	joined := &serrors.ErrorWithContext{
+		BaseError: base,
+		Context:   serrors.ReversedStringsList(context),
+	}
+	joined.Context = append(joined.Context, "$."+strings.Join(path, "."))
+	return joined
+}
+
+func parseJSONPath(context string) []string {
+	if !strings.HasPrefix(context, "$.") {
+		return nil
+	}
+
+	path := []string{}
+	for _, segment := range strings.Split(strings.TrimPrefix(context, "$."), ".") {
+		if len(segment) > 0 {
+			path = append(path, segment)
+		}
+	}
+	return path
+}
@@ -0,0 +1,129 @@
+package yaml
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+const positionSource = `config_map:
+  name: test
+  labels:
+    app.kubernetes.io/name: web
+  items:
+  - first
+  - second
+`
+
+func TestLocate(t *testing.T) {
+	s := &Source{Filename: "test.yaml", LineOffset: 10, Data: []byte(positionSource)}
+
+	testcases := []struct {
+		description string
+		path        []string
+		expected    Position
+	}{
+		{
+			description: "root",
+			path:        []string{},
+			expected:    Position{Filename: "test.yaml", Line: 11, Column: 1},
+		},
+		{
+			description: "map value",
+			path:        []string{"config_map", "name"},
+			expected:    Position{Filename: "test.yaml", Line: 12, Column: 9},
+		},
+		{
+			description: "key containing dots",
+			path:        []string{"config_map", "labels", "app", "kubernetes", "io/name"},
+			expected:    Position{Filename: "test.yaml", Line: 14, Column: 29},
+		},
+		{
+			description: "sequence item",
+			path:        []string{"config_map", "items", "1"},
+			expected:    Position{Filename: "test.yaml", Line: 17, Column: 5},
+		},
+		{
+			description: "missing key falls back to deepest node",
+			path:        []string{"config_map", "missing"},
+			expected:    Position{Filename: "test.yaml", Line: 12, Column: 3},
+		},
+	}
+
+	for _, tc := range testcases {
+		pos := s.Locate(tc.path)
+		if pos != tc.expected {
+			t.Errorf("%s: expected %v got %v", tc.description, tc.expected, pos)
+		}
+	}
+}
+
+type positionTarget struct {
+	ConfigMap struct {
+		Name  string   `json:"name"`
+		Items []string `json:"items"`
+	} `json:"config_map"`
+}
+
+func TestUnmarshalErrorPosition(t *testing.T) {
+	y := []byte("config_map:\n  name: test\n  items:\n  - a\n  - b: c\n")
+	err := UnmarshalSource(&Source{Data: y}, &positionTarget{})
+	posErr, ok := err.(*PositionError)
+	if !ok {
+		t.Fatalf("expected *PositionError got %T (%v)", err, err)
+	}
+
+	expected := Position{Line: 5, Column: 5}
+	if posErr.Position != expected {
+		t.Errorf("expected %v got %v", expected, posErr.Position)
+	}
+	if !strings.Contains(posErr.Excerpt, "5 |   - b: c") {
+		t.Errorf("excerpt missing offending line:\n%s", posErr.Excerpt)
+	}
+}
+
+func TestAnnotateSyntaxError(t *testing.T) {
+	s := &Source{Filename: "test.yaml", LineOffset: 4, Data: []byte("a: b\nc: : d\n")}
+	err := UnmarshalSource(s, &map[string]interface{}{})
+	posErr, ok := err.(*PositionError)
+	if !ok {
+		t.Fatalf("expected *PositionError got %T (%v)", err, err)
+	}
+
+	expected := Position{Filename: "test.yaml", Line: 6}
+	if posErr.Position != expected {
+		t.Errorf("expected %v got %v", expected, posErr.Position)
+	}
+	if strings.Contains(posErr.Err.Error(), "line 2") {
+		t.Errorf("expected document relative line to be removed: %v", posErr.Err)
+	}
+}
+
+func TestErrorPath(t *testing.T) {
+	err := UnmarshalSource(&Source{Data: []byte("config_map:\n  items:\n  - [1]\n")}, &positionTarget{})
+	path := ErrorPath(err)
+	expected := []string{"config_map", "items", "0"}
+	if !reflect.DeepEqual(path, expected) {
+		t.Errorf("expected %v got %v", expected, path)
+	}
+}
+
+func TestJoinErrorPath(t *testing.T) {
+	err := errors.New("bad value")
+	err = serrors.ContextualizeErrorf(err, "$.containerPort")
+	err = serrors.ContextualizeErrorf(err, "port")
+	err = serrors.ContextualizeErrorf(err, "$.containers.0")
+	err = serrors.ContextualizeErrorf(err, "$.pod")
+
+	joined := JoinErrorPath(err)
+	expected := "$.pod.containers.0.containerPort: port: bad value"
+	if joined.Error() != expected {
+		t.Errorf("expected %q got %q", expected, joined.Error())
+	}
+	if path := ErrorPath(joined); !reflect.DeepEqual(path, []string{"pod", "containers", "0", "containerPort"}) {
+		t.Errorf("unexpected path %v", path)
+	}
+}
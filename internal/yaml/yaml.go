@@ -1,3 +1,9 @@
+// This file is vendored from github.com/ghodss/yaml, using
+// github.com/koki/json in place of encoding/json.  The only other change
+// from upstream is the unreachable return dropped from the end of
+// convertToJSONableObject, which go vet rejects.  Additions belong in
+// their own files, such as position.go.
+
 package yaml
 
 import (
@@ -273,6 +279,4 @@ func convertToJSONableObject(yamlObj interface{}, jsonTarget *reflect.Value) (in
 		}
 		return yamlObj, nil
 	}
-
-	return nil, nil
 }
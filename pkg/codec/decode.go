@@ -1,49 +1,59 @@
 package codec
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
 	"io"
-	"io/ioutil"
 
-	"mantle/internal/yaml"
-	"mantle/pkg/core"
+	_ "mantle/pkg/core"
+	"mantle/pkg/registry"
+
+	"github.com/koki/json/jsonutil"
+	serrors "github.com/koki/structurederrors"
 
-	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func Decode(input io.Reader) (io.Reader, error) {
-	data, err := ioutil.ReadAll(input)
-	if err != nil {
-		return nil, err
-	}
-	obj := map[string]interface{}{}
-	err = yaml.Unmarshal(data, &obj)
+// Decode reads a stream of kubernetes manifests and mantle documents from
+// input and converts each document into the other format.  The format of
+// each document is detected from its contents, and the converted
+// documents are written in the order they were read.
+func Decode(input io.Reader, opts Options) (io.Reader, error) {
+	docs, err := readDocuments(input, opts.Filename)
 	if err != nil {
 		return nil, err
 	}
 
-	kubeObj, err := ParseKubeNativeType(obj)
-	if err != nil {
-		//TBD: parse koki type
-		return nil, err
+	outs := []output{}
+	for _, doc := range docs {
+		var converted []interface{}
+		if IsKubeNativeType(doc.obj) {
+			converted, err = convertKubeNativeTypes(doc.obj)
+		} else {
+			var kubeObj runtime.Object
+			kubeObj, err = ParseMantleType(doc.obj)
+			converted = []interface{}{kubeObj}
+		}
+		if err != nil {
+			return nil, documentErrorf(doc.source.Annotate(err), doc.index)
+		}
+		for _, obj := range converted {
+			outs = append(outs, output{index: doc.index, obj: obj})
+		}
 	}
 
-	switch kubeTypedObj := kubeObj.(type) {
-	case *v1.ConfigMap:
-		cm, err := core.NewConfigMapFromKubeConfigMap(kubeTypedObj)
-		if err != nil {
-			return nil, err
+	return writeDocuments(outs, opts)
+}
+
+// IsKubeNativeType returns true if the document describes a kubernetes
+// object, i.e. it sets both apiVersion and kind
+func IsKubeNativeType(obj map[string]interface{}) bool {
+	for _, key := range []string{"apiVersion", "kind"} {
+		val, ok := obj[key].(string)
+		if !ok || len(val) == 0 {
+			return false
 		}
-		buf := &bytes.Buffer{}
-		encoder := json.NewEncoder(buf)
-		err = encoder.Encode(cm)
-		return buf, err
 	}
-	return nil, errors.New("unreachable")
+	return true
 }
 
 func ParseKubeNativeType(obj map[string]interface{}) (runtime.Object, error) {
@@ -56,8 +66,64 @@ func ParseKubeNativeType(obj map[string]interface{}) (runtime.Object, error) {
 		return nil, err
 	}
 
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, typedObj); err != nil {
+	// Decoding through json, rather than the unstructured converter,
+	// reports which field of the document has the wrong type
+	if err := jsonutil.UnmarshalMap(obj, typedObj); err != nil {
 		return nil, err
 	}
 	return typedObj, nil
 }
+
+// convertKubeNativeTypes converts a kubernetes document into mantle
+// documents.  Lists are converted into one mantle document per item.
+func convertKubeNativeTypes(obj map[string]interface{}) ([]interface{}, error) {
+	if !IsKubeList(obj) {
+		mantleObj, err := convertKubeNativeType(obj)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{mantleObj}, nil
+	}
+
+	items, err := expandKubeList(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	mantleObjs := []interface{}{}
+	for i, item := range items {
+		converted, err := convertKubeNativeTypes(item)
+		if err != nil {
+			return nil, listItemErrorf(err, i)
+		}
+		mantleObjs = append(mantleObjs, converted...)
+	}
+	return mantleObjs, nil
+}
+
+// convertKubeNativeType converts a kubernetes document into a mantle
+// document keyed by the mantle type name
+func convertKubeNativeType(obj map[string]interface{}) (map[string]interface{}, error) {
+	kubeObj, err := ParseKubeNativeType(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	gvk := kubeObj.GetObjectKind().GroupVersionKind()
+	converter, ok := registry.ForKind(gvk)
+	if !ok {
+		return nil, serrors.TypeErrorf(kubeObj, "no mantle conversion for %s", gvk)
+	}
+
+	mantleObj, err := converter.FromKube(kubeObj)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{converter.Name: mantleObj}, nil
+}
+
+// documentErrorf adds the index of the failing document in the input
+// stream to err
+func documentErrorf(err error, index int) error {
+	return serrors.ContextualizeErrorf(err, "document %d", index)
+}
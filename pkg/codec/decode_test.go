@@ -0,0 +1,219 @@
+package codec
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		opts        Options
+		expected    string
+	}{
+		{
+			description: "kubernetes yaml",
+			input: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+data:
+  key: value
+`,
+			expected: `config_map:
+  data:
+    key: value
+  name: app
+  version: v1
+`,
+		},
+		{
+			description: "kubernetes json",
+			input:       `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "app"}}`,
+			expected: `config_map:
+  name: app
+  version: v1
+`,
+		},
+		{
+			description: "v1 list",
+			input: `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: b
+`,
+			expected: `config_map:
+  name: a
+  version: v1
+---
+secret:
+  name: b
+  version: v1
+`,
+		},
+		{
+			description: "typed list",
+			input: `apiVersion: v1
+kind: ConfigMapList
+items:
+- metadata:
+    name: a
+- metadata:
+    name: b
+`,
+			expected: `config_map:
+  name: a
+  version: v1
+---
+config_map:
+  name: b
+  version: v1
+`,
+		},
+		{
+			description: "mixed stream keeps its order",
+			input: `config_map:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`,
+			expected: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+config_map:
+  name: b
+  version: v1
+`,
+		},
+		{
+			description: "wrapped list takes the place of the first kubernetes object",
+			input: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+config_map:
+  name: b
+---
+config_map:
+  name: c
+`,
+			opts: Options{WrapList: true},
+			expected: `config_map:
+  name: a
+  version: v1
+---
+apiVersion: v1
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: c
+kind: List
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		out, err := Decode(strings.NewReader(tc.input), tc.opts)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.description, err)
+			continue
+		}
+		data, _ := ioutil.ReadAll(out)
+		if string(data) != tc.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tc.description, tc.expected, data)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		expected    string
+	}{
+		{
+			description: "documents are counted after a leading separator",
+			input: `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+data:
+  key:
+    nested: value
+`,
+			expected: `document 1: test.yaml:13:5: $.data.key: json: cannot unmarshal object into Go value of type string
+12 |   key:
+13 |     nested: value
+   |     ^`,
+		},
+		{
+			description: "list items are located",
+			input: `apiVersion: v1
+kind: ConfigMapList
+items:
+- metadata:
+    name: a
+- metadata:
+    name: b
+  data:
+    key:
+      nested: value
+`,
+			expected: `document 0: test.yaml:10:7: $.items.1.data.key: json: cannot unmarshal object into Go value of type string
+ 9 |     key:
+10 |       nested: value
+   |       ^`,
+		},
+		{
+			description: "converted mantle document",
+			input: `config_map:
+  name: a
+---
+pod:
+  name: b
+  restartPolicy: sometimes
+`,
+			expected: `document 1: test.yaml:6:18: $.pod.restartPolicy: (pod.RestartPolicy) value: unrecognized restart policy
+5 |   name: b
+6 |   restartPolicy: sometimes
+  |                  ^`,
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := Decode(strings.NewReader(tc.input), Options{Filename: "test.yaml"})
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.description)
+			continue
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("%s: expected error\n%s\ngot\n%s", tc.description, tc.expected, err)
+		}
+	}
+}
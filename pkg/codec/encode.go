@@ -1 +1,84 @@
 package codec
+
+import (
+	"io"
+
+	"mantle/pkg/registry"
+
+	"github.com/koki/json/jsonutil"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Encode reads a stream of mantle documents from input and returns the
+// equivalent kubernetes manifests in the same order
+func Encode(input io.Reader, opts Options) (io.Reader, error) {
+	docs, err := readDocuments(input, opts.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	outs := make([]output, len(docs))
+	for i, doc := range docs {
+		kubeObj, err := ParseMantleType(doc.obj)
+		if err != nil {
+			return nil, documentErrorf(doc.source.Annotate(err), doc.index)
+		}
+		outs[i] = output{index: doc.index, obj: kubeObj}
+	}
+
+	return writeDocuments(outs, opts)
+}
+
+// ParseMantleType converts a mantle document into the kubernetes object it
+// describes.  The document must contain a single key naming the resource
+// type, e.g. "config_map", whose value holds the resource definition.
+func ParseMantleType(obj map[string]interface{}) (runtime.Object, error) {
+	mantleType, val, err := jsonutil.GetOnlyMapEntry(obj)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "mantle document should contain exactly one resource")
+	}
+
+	body, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(val, "expected a dictionary"), "$.%s", mantleType)
+	}
+
+	converter, ok := registry.ForName(mantleType)
+	if !ok {
+		return nil, serrors.InvalidValueErrorf(mantleType, "unsupported mantle type (%s), expected one of %v", mantleType, registry.Names())
+	}
+
+	mantleObj := converter.New()
+	err = jsonutil.UnmarshalMap(body, mantleObj)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.%s", mantleType)
+	}
+
+	kubeObj, err := mantleObj.ToKube()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.%s", mantleType)
+	}
+
+	err = setKubeNativeType(kubeObj)
+	if err != nil {
+		return nil, err
+	}
+	return kubeObj, nil
+}
+
+// setKubeNativeType fills in the apiVersion and kind of a typed kubernetes
+// object from the scheme, since the mantle document may leave them out.
+// Objects unknown to the scheme must set them on their own.
+func setKubeNativeType(obj runtime.Object) error {
+	gvks, _, err := creator.ObjectKinds(obj)
+	if err != nil {
+		if !obj.GetObjectKind().GroupVersionKind().Empty() {
+			return nil
+		}
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+	return nil
+}
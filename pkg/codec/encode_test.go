@@ -0,0 +1,162 @@
+package codec
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	input := `config_map:
+  name: a
+  data:
+    key: value
+---
+config_map:
+  name: b
+`
+
+	testcases := []struct {
+		description string
+		opts        Options
+		expected    string
+	}{
+		{
+			description: "yaml",
+			expected: `apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`,
+		},
+		{
+			description: "json",
+			opts:        Options{Format: FormatJSON},
+			expected: `{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"a"},"data":{"key":"value"}}
+{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"b"}}
+`,
+		},
+		{
+			description: "pretty json",
+			opts:        Options{Format: FormatPrettyJSON},
+			expected: `{
+  "kind": "ConfigMap",
+  "apiVersion": "v1",
+  "metadata": {
+    "name": "a"
+  },
+  "data": {
+    "key": "value"
+  }
+}
+{
+  "kind": "ConfigMap",
+  "apiVersion": "v1",
+  "metadata": {
+    "name": "b"
+  }
+}
+`,
+		},
+		{
+			description: "wrapped list",
+			opts:        Options{WrapList: true},
+			expected: `apiVersion: v1
+items:
+- apiVersion: v1
+  data:
+    key: value
+  kind: ConfigMap
+  metadata:
+    name: a
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+kind: List
+`,
+		},
+		{
+			description: "wrapped list as json",
+			opts:        Options{Format: FormatJSON, WrapList: true},
+			expected: `{"kind":"List","apiVersion":"v1","items":[{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"a"},"data":{"key":"value"}},{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"b"}}]}
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		out, err := Encode(strings.NewReader(input), tc.opts)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.description, err)
+			continue
+		}
+		data, _ := ioutil.ReadAll(out)
+		if string(data) != tc.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tc.description, tc.expected, data)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		opts        Options
+		expected    string
+	}{
+		{
+			description: "documents are counted among those kept",
+			input: `---
+---
+config_map:
+  name: a
+---
+pod:
+  name: b
+  containers:
+  - image: nginx
+    ports:
+    - 80/sctp
+`,
+			expected: `document 1: test.yaml:11:7: $.pod.containers.0.ports.0: (string) value: unsupported protocol, expected tcp or udp
+10 |     ports:
+11 |     - 80/sctp
+   |       ^`,
+		},
+		{
+			description: "more than one resource",
+			input:       "config_map:\n  name: a\nsecret:\n  name: b\n",
+			expected:    "document 0: test.yaml:1:1: mantle document should contain exactly one resource",
+		},
+		{
+			description: "unknown resource",
+			input:       "map:\n  name: a\n",
+			expected:    "document 0: test.yaml:1:1: (string) value: unsupported mantle type (map)",
+		},
+		{
+			description: "unknown format",
+			input:       "config_map:\n  name: a\n",
+			opts:        Options{Format: "xml"},
+			expected:    "unsupported output format (xml)",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc.opts.Filename = "test.yaml"
+		_, err := Encode(strings.NewReader(tc.input), tc.opts)
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.description)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), tc.expected) {
+			t.Errorf("%s: expected an error starting with\n%s\ngot\n%s", tc.description, tc.expected, err)
+		}
+	}
+}
@@ -48,6 +48,13 @@ func init() {
 	AddToScheme(creator)
 }
 
+// RegisterKubeTypes adds kubernetes types that are not built into the
+// codec, e.g. custom resources, so that documents of those kinds can be
+// parsed and handed to a registered converter
+func RegisterKubeTypes(addToScheme func(*runtime.Scheme) error) error {
+	return addToScheme(creator)
+}
+
 func AddToScheme(scheme *runtime.Scheme) {
 	admissionregistrationv1alpha1.AddToScheme(scheme)
 	admissionregistrationv1beta1.AddToScheme(scheme)
@@ -0,0 +1,92 @@
+package codec
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	kubeKindList = "List"
+)
+
+// IsKubeList returns true if the kubernetes document is a v1 List or a
+// typed list such as ConfigMapList
+func IsKubeList(obj map[string]interface{}) bool {
+	kind, _ := obj["kind"].(string)
+	if !strings.HasSuffix(kind, kubeKindList) {
+		return false
+	}
+	_, ok := obj["items"].([]interface{})
+	return ok
+}
+
+// expandKubeList returns the items of a kubernetes list document.  Items
+// of typed lists usually leave out apiVersion and kind, so they are
+// filled in from the list itself.
+func expandKubeList(obj map[string]interface{}) ([]map[string]interface{}, error) {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	itemKind := strings.TrimSuffix(kind, kubeKindList)
+
+	items := obj["items"].([]interface{})
+	kubeObjs := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		kubeObj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, listItemErrorf(serrors.InvalidValueErrorf(item, "expected a dictionary"), i)
+		}
+
+		if len(itemKind) > 0 {
+			if _, ok := kubeObj["apiVersion"]; !ok {
+				kubeObj["apiVersion"] = apiVersion
+			}
+			if _, ok := kubeObj["kind"]; !ok {
+				kubeObj["kind"] = itemKind
+			}
+		}
+		kubeObjs[i] = kubeObj
+	}
+
+	return kubeObjs, nil
+}
+
+// wrapKubeList replaces the kubernetes objects in outs with a single v1
+// List holding all of them.  The list takes the position of the first
+// kubernetes object so mantle documents keep their relative order.
+func wrapKubeList(outs []output) ([]output, error) {
+	list := &v1.List{}
+	err := setKubeNativeType(list)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped := []output{}
+	for _, out := range outs {
+		kubeObj, ok := out.obj.(runtime.Object)
+		if !ok {
+			wrapped = append(wrapped, out)
+			continue
+		}
+		if len(list.Items) == 0 {
+			wrapped = append(wrapped, output{index: out.index, obj: list})
+		}
+		// The item is marshalled up front, since RawExtension would use
+		// encoding/json and write fields that the other documents leave out
+		raw, err := json.Marshal(kubeObj)
+		if err != nil {
+			return nil, documentErrorf(err, out.index)
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: raw, Object: kubeObj})
+	}
+
+	return wrapped, nil
+}
+
+func listItemErrorf(err error, index int) error {
+	return serrors.ContextualizeErrorf(err, "$.items.%d", index)
+}
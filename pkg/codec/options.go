@@ -0,0 +1,49 @@
+package codec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is the serialization used for converted documents
+type Format string
+
+const (
+	// FormatYAML writes "---" separated YAML documents
+	FormatYAML Format = "yaml"
+	// FormatJSON writes one compact JSON object per line
+	FormatJSON Format = "json"
+	// FormatPrettyJSON writes indented JSON objects
+	FormatPrettyJSON Format = "pretty-json"
+)
+
+// Formats lists every supported output format
+var Formats = []Format{FormatYAML, FormatJSON, FormatPrettyJSON}
+
+// ParseFormat returns the format with the given name.  An empty name
+// selects YAML.
+func ParseFormat(name string) (Format, error) {
+	if len(name) == 0 {
+		return FormatYAML, nil
+	}
+	for _, format := range Formats {
+		if strings.ToLower(name) == string(format) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output format (%s), expected one of %v", name, Formats)
+}
+
+// Options control how Decode and Encode convert documents
+type Options struct {
+	// Filename names the input in error messages
+	Filename string
+
+	// Format is the serialization of the converted documents.  YAML is
+	// used when it is not set.
+	Format Format
+
+	// WrapList collects the kubernetes objects produced by a conversion
+	// into a single v1 List instead of writing one document per object
+	WrapList bool
+}
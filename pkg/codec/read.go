@@ -0,0 +1,126 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"mantle/internal/yaml"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	streamBufferSize = 4096
+)
+
+// document is a single document of an input stream along with its
+// source and index in the stream, which are used to report the position
+// of conversion errors
+type document struct {
+	source *yaml.Source
+	index  int
+	obj    map[string]interface{}
+}
+
+// readDocuments splits input into its documents and unmarshals each of
+// them.  Input is either a YAML stream of "---" separated documents or a
+// stream of JSON objects.  Empty documents are skipped.
+func readDocuments(input io.Reader, filename string) ([]*document, error) {
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []*yaml.Source
+	if _, _, isJSON := utilyaml.GuessJSONStream(bytes.NewReader(data), streamBufferSize); isJSON {
+		sources, err = splitJSONDocuments(data, filename)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		sources = splitYAMLDocuments(data, filename)
+	}
+
+	// Documents are numbered by their position among the documents that
+	// are kept, so empty documents don't shift the index of later ones
+	docs := []*document{}
+	for _, source := range sources {
+		obj := map[string]interface{}{}
+		err = yaml.UnmarshalSource(source, &obj)
+		if err != nil {
+			return nil, documentErrorf(err, len(docs))
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		docs = append(docs, &document{source: source, index: len(docs), obj: obj})
+	}
+	return docs, nil
+}
+
+// splitYAMLDocuments splits data on "---" separator lines
+func splitYAMLDocuments(data []byte, filename string) []*yaml.Source {
+	sources := []*yaml.Source{}
+	start, startLine, line := 0, 0, 0
+	for offset := 0; offset < len(data); line++ {
+		next := len(data)
+		if i := bytes.IndexByte(data[offset:], '\n'); i >= 0 {
+			next = offset + i + 1
+		}
+
+		if isYAMLSeparator(data[offset:next]) {
+			sources = append(sources, &yaml.Source{
+				Filename:   filename,
+				LineOffset: startLine,
+				Data:       data[start:offset],
+			})
+			start, startLine = next, line+1
+		}
+		offset = next
+	}
+
+	return append(sources, &yaml.Source{
+		Filename:   filename,
+		LineOffset: startLine,
+		Data:       data[start:],
+	})
+}
+
+// isYAMLSeparator returns true if line is a "---" document marker,
+// optionally followed by a comment
+func isYAMLSeparator(line []byte) bool {
+	if !bytes.HasPrefix(line, []byte(yamlSeparator)) {
+		return false
+	}
+	rest := line[len(yamlSeparator):]
+	trimmed := bytes.TrimSpace(rest)
+	if len(trimmed) == 0 {
+		return true
+	}
+	return trimmed[0] == '#' && len(bytes.TrimLeft(rest, " \t")) < len(rest)
+}
+
+// splitJSONDocuments splits a stream of concatenated JSON objects
+func splitJSONDocuments(data []byte, filename string) ([]*yaml.Source, error) {
+	sources := []*yaml.Source{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		raw := json.RawMessage{}
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return sources, nil
+		}
+		if err != nil {
+			return nil, documentErrorf(err, len(sources))
+		}
+
+		start := int(decoder.InputOffset()) - len(raw)
+		sources = append(sources, &yaml.Source{
+			Filename:   filename,
+			LineOffset: bytes.Count(data[:start], []byte("\n")),
+			Data:       raw,
+		})
+	}
+}
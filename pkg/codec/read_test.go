@@ -0,0 +1,108 @@
+package codec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadDocuments(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		// expected holds the line offset of each document that is kept
+		expected []int
+		objs     []map[string]interface{}
+	}{
+		{
+			description: "yaml stream",
+			input:       "a: x\n---\nb: z\n",
+			expected:    []int{0, 2},
+			objs:        []map[string]interface{}{{"a": "x"}, {"b": "z"}},
+		},
+		{
+			description: "leading separator",
+			input:       "---\na: x\n---\nb: z\n",
+			expected:    []int{1, 3},
+			objs:        []map[string]interface{}{{"a": "x"}, {"b": "z"}},
+		},
+		{
+			description: "separator with a comment",
+			input:       "a: x\n--- # second\nb: z\n",
+			expected:    []int{0, 2},
+			objs:        []map[string]interface{}{{"a": "x"}, {"b": "z"}},
+		},
+		{
+			description: "separator inside a block scalar",
+			input:       "a: |\n  ---\nb: z\n",
+			expected:    []int{0},
+			objs:        []map[string]interface{}{{"a": "---\n", "b": "z"}},
+		},
+		{
+			description: "empty documents are skipped",
+			input:       "---\n---\n# nothing\n---\na: x\n",
+			expected:    []int{4},
+			objs:        []map[string]interface{}{{"a": "x"}},
+		},
+		{
+			description: "json stream",
+			input:       "{\"a\": \"x\"}\n{\n  \"b\": \"z\"\n}\n",
+			expected:    []int{0, 1},
+			objs:        []map[string]interface{}{{"a": "x"}, {"b": "z"}},
+		},
+	}
+
+	for _, tc := range testcases {
+		docs, err := readDocuments(strings.NewReader(tc.input), "test.yaml")
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.description, err)
+			continue
+		}
+
+		offsets := []int{}
+		objs := []map[string]interface{}{}
+		for i, doc := range docs {
+			if doc.index != i {
+				t.Errorf("%s: expected document %d to have index %d, got %d", tc.description, i, i, doc.index)
+			}
+			offsets = append(offsets, doc.source.LineOffset)
+			objs = append(objs, doc.obj)
+		}
+		if !reflect.DeepEqual(offsets, tc.expected) {
+			t.Errorf("%s: expected line offsets %v, got %v", tc.description, tc.expected, offsets)
+		}
+		if !reflect.DeepEqual(objs, tc.objs) {
+			t.Errorf("%s: expected documents %v, got %v", tc.description, tc.objs, objs)
+		}
+	}
+}
+
+func TestReadDocumentsErrors(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		expected    string
+	}{
+		{
+			description: "yaml syntax error",
+			input:       "---\na: x\n---\nb: [\n",
+			expected:    "document 1: test.yaml:",
+		},
+		{
+			description: "json syntax error",
+			input:       "{\"a\": \"x\"}\n{\"b\": }\n",
+			expected:    "document 1: ",
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := readDocuments(strings.NewReader(tc.input), "test.yaml")
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.description)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), tc.expected) {
+			t.Errorf("%s: expected an error starting with %q, got %q", tc.description, tc.expected, err)
+		}
+	}
+}
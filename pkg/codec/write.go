@@ -0,0 +1,76 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+
+	"mantle/internal/yaml"
+
+	"github.com/koki/json"
+)
+
+const (
+	yamlSeparator = "---"
+	jsonIndent    = "  "
+)
+
+// output is a converted document along with the index of the input
+// document it was converted from, which is used to report errors
+type output struct {
+	index int
+	obj   interface{}
+}
+
+func writeDocuments(outs []output, opts Options) (io.Reader, error) {
+	if opts.WrapList {
+		var err error
+		outs, err = wrapKubeList(outs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	format, err := ParseFormat(string(opts.Format))
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	for i, out := range outs {
+		var data []byte
+		switch format {
+		case FormatYAML:
+			if i > 0 {
+				buf.WriteString(yamlSeparator + "\n")
+			}
+			data, err = yaml.Marshal(out.obj)
+		case FormatJSON:
+			data, err = marshalJSON(out.obj, "")
+		case FormatPrettyJSON:
+			data, err = marshalJSON(out.obj, jsonIndent)
+		}
+		if err != nil {
+			return nil, documentErrorf(err, out.index)
+		}
+		buf.Write(data)
+	}
+	return buf, nil
+}
+
+// marshalJSON marshals obj as a newline terminated JSON document.  The
+// document is indented when indent is not empty.  Like yaml.Marshal it
+// uses the koki json package, so empty fields such as a null
+// creationTimestamp are left out in every format.
+func marshalJSON(obj interface{}, indent string) ([]byte, error) {
+	var data []byte
+	var err error
+	if len(indent) > 0 {
+		data, err = json.MarshalIndent(obj, "", indent)
+	} else {
+		data, err = json.Marshal(obj)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,22 @@
+package clusterrole
+
+import (
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/rbac"
+)
+
+// ClusterRole defines a cluster role object, which grants its rules in
+// every namespace and on cluster scoped resources.  The rules of an
+// aggregated cluster role are filled in by the controller manager from
+// the cluster roles matching any of its aggregation selectors.
+type ClusterRole struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Rules     []rbac.Rule              `json:"rules,omitempty"`
+	Aggregate []selector.LabelSelector `json:"aggregate,omitempty"`
+}
@@ -0,0 +1,53 @@
+package clusterrole
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterRoleRoundTrip(t *testing.T) {
+	kubeClusterRole := &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "monitoring",
+		},
+		Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics"}},
+		},
+		AggregationRule: &rbacv1.AggregationRule{
+			ClusterRoleSelectors: []metav1.LabelSelector{
+				{MatchLabels: map[string]string{"rbac.example.com/aggregate-to-monitoring": "true"}},
+			},
+		},
+	}
+
+	r, err := NewClusterRoleFromKubeClusterRole(kubeClusterRole)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	r = &ClusterRole{}
+	if err := json.Unmarshal(data, r); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := r.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeClusterRole) {
+		t.Errorf("round trip changed the cluster role\nexpected %#v\ngot      %#v", kubeClusterRole, obj)
+	}
+}
@@ -0,0 +1,80 @@
+package clusterrole
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/rbac"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+)
+
+// NewClusterRoleFromKubeClusterRole will create a new ClusterRole object
+// with the data from a provided kubernetes cluster role object of any
+// supported api version
+func NewClusterRoleFromKubeClusterRole(obj interface{}) (*ClusterRole, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(rbacv1.ClusterRole{}):
+		o := obj.(rbacv1.ClusterRole)
+		return fromKubeClusterRoleV1(&o)
+	case reflect.TypeOf(&rbacv1.ClusterRole{}):
+		return fromKubeClusterRoleV1(obj.(*rbacv1.ClusterRole))
+	case reflect.TypeOf(rbacv1beta1.ClusterRole{}):
+		o := obj.(rbacv1beta1.ClusterRole)
+		return fromKubeClusterRoleViaV1(&o)
+	case reflect.TypeOf(&rbacv1beta1.ClusterRole{}):
+		return fromKubeClusterRoleViaV1(obj.(*rbacv1beta1.ClusterRole))
+	case reflect.TypeOf(rbacv1alpha1.ClusterRole{}):
+		o := obj.(rbacv1alpha1.ClusterRole)
+		return fromKubeClusterRoleViaV1(&o)
+	case reflect.TypeOf(&rbacv1alpha1.ClusterRole{}):
+		return fromKubeClusterRoleViaV1(obj.(*rbacv1alpha1.ClusterRole))
+	default:
+		return nil, fmt.Errorf("unknown ClusterRole version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeClusterRoleV1(kubeClusterRole *rbacv1.ClusterRole) (*ClusterRole, error) {
+	rules, err := rbac.NewRulesFromKubePolicyRulesV1(kubeClusterRole.Rules)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.rules")
+	}
+
+	clusterRole := &ClusterRole{
+		Name:        kubeClusterRole.Name,
+		Namespace:   kubeClusterRole.Namespace,
+		Version:     kubeClusterRole.APIVersion,
+		Cluster:     kubeClusterRole.ClusterName,
+		Labels:      kubeClusterRole.Labels,
+		Annotations: kubeClusterRole.Annotations,
+		Rules:       rules,
+	}
+
+	if kubeClusterRole.AggregationRule != nil {
+		for i := range kubeClusterRole.AggregationRule.ClusterRoleSelectors {
+			s := selector.NewLabelSelectorFromKubeLabelSelectorV1(&kubeClusterRole.AggregationRule.ClusterRoleSelectors[i])
+			clusterRole.Aggregate = append(clusterRole.Aggregate, *s)
+		}
+	}
+
+	return clusterRole, nil
+}
+
+// fromKubeClusterRoleViaV1 converts a cluster role of an older api version,
+// which has the same fields as rbac v1.  The api version of the original
+// is kept.
+func fromKubeClusterRoleViaV1(kubeClusterRole interface{}) (*ClusterRole, error) {
+	v1ClusterRole := &rbacv1.ClusterRole{}
+	err := converterutils.ConvertKubeObject(kubeClusterRole, v1ClusterRole)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeClusterRole, "couldn't convert to %s: %s", rbacv1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeClusterRoleV1(v1ClusterRole)
+}
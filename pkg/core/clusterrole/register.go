@@ -0,0 +1,34 @@
+package clusterrole
+
+import (
+	"mantle/pkg/registry"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "cluster_role"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			rbacv1.SchemeGroupVersion.WithKind("ClusterRole"),
+			rbacv1alpha1.SchemeGroupVersion.WithKind("ClusterRole"),
+			rbacv1beta1.SchemeGroupVersion.WithKind("ClusterRole"),
+		},
+		New: func() registry.Object {
+			return &ClusterRole{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			r, err := NewClusterRoleFromKubeClusterRole(obj)
+			if err != nil {
+				return nil, err
+			}
+			return r, nil
+		},
+	})
+}
@@ -0,0 +1,101 @@
+package clusterrole
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/rbac"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes cluster role object of the api version
+// type defined in the object
+func (r *ClusterRole) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(r.Version) {
+	case "rbac.authorization.k8s.io/v1":
+		return r.toKubeV1()
+	case "":
+		return r.toKubeV1()
+	case "rbac.authorization.k8s.io/v1beta1":
+		return r.toKubeV1beta1()
+	case "rbac.authorization.k8s.io/v1alpha1":
+		return r.toKubeV1alpha1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for cluster role: %s", r.Version)
+	}
+}
+
+func (r *ClusterRole) toKubeV1() (*rbacv1.ClusterRole, error) {
+	rules, err := rbac.NewKubePolicyRulesV1(r.Rules)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.rules")
+	}
+
+	kubeClusterRole := &rbacv1.ClusterRole{}
+	kubeClusterRole.Name = r.Name
+	kubeClusterRole.Namespace = r.Namespace
+	kubeClusterRole.APIVersion = "rbac.authorization.k8s.io/v1"
+	kubeClusterRole.ClusterName = r.Cluster
+	kubeClusterRole.Kind = "ClusterRole"
+	kubeClusterRole.Labels = r.Labels
+	kubeClusterRole.Annotations = r.Annotations
+	kubeClusterRole.Rules = rules
+
+	if len(r.Aggregate) > 0 {
+		selectors := []metav1.LabelSelector{}
+		for i := range r.Aggregate {
+			selectors = append(selectors, *r.Aggregate[i].ToKubeLabelSelectorV1())
+		}
+		kubeClusterRole.AggregationRule = &rbacv1.AggregationRule{
+			ClusterRoleSelectors: selectors,
+		}
+	}
+
+	return kubeClusterRole, nil
+}
+
+func (r *ClusterRole) toKubeV1beta1() (*rbacv1beta1.ClusterRole, error) {
+	kubeClusterRole := &rbacv1beta1.ClusterRole{}
+	err := r.toKubeViaV1(kubeClusterRole)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClusterRole.SetGroupVersionKind(rbacv1beta1.SchemeGroupVersion.WithKind("ClusterRole"))
+	return kubeClusterRole, nil
+}
+
+func (r *ClusterRole) toKubeV1alpha1() (*rbacv1alpha1.ClusterRole, error) {
+	kubeClusterRole := &rbacv1alpha1.ClusterRole{}
+	err := r.toKubeViaV1(kubeClusterRole)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClusterRole.SetGroupVersionKind(rbacv1alpha1.SchemeGroupVersion.WithKind("ClusterRole"))
+	return kubeClusterRole, nil
+}
+
+// toKubeViaV1 converts the cluster role to rbac v1 and from there into
+// kubeClusterRole, a cluster role of an older api version
+func (r *ClusterRole) toKubeViaV1(kubeClusterRole interface{}) error {
+	v1ClusterRole, err := r.toKubeV1()
+	if err != nil {
+		return err
+	}
+
+	err = converterutils.ConvertKubeObject(v1ClusterRole, kubeClusterRole)
+	if err != nil {
+		return serrors.InvalidInstanceErrorf(r, "couldn't convert to %s: %s", r.Version, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package clusterrolebinding
+
+import (
+	"mantle/pkg/core/rbac"
+)
+
+// ClusterRoleBinding defines a cluster role binding object, which grants
+// the rules of a cluster role to its subjects in every namespace
+type ClusterRoleBinding struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Subjects []rbac.Subject `json:"subjects,omitempty"`
+	RoleRef  rbac.RoleRef   `json:"roleRef"`
+}
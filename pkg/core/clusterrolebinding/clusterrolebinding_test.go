@@ -0,0 +1,53 @@
+package clusterrolebinding
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterRoleBindingRoundTrip(t *testing.T) {
+	kubeClusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster-admins",
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: "Group", APIGroup: "rbac.authorization.k8s.io", Name: "system:masters"},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+		},
+	}
+
+	b, err := NewClusterRoleBindingFromKubeClusterRoleBinding(kubeClusterRoleBinding)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	b = &ClusterRoleBinding{}
+	if err := json.Unmarshal(data, b); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := b.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeClusterRoleBinding) {
+		t.Errorf("round trip changed the cluster role binding\nexpected %#v\ngot      %#v", kubeClusterRoleBinding, obj)
+	}
+}
@@ -0,0 +1,76 @@
+package clusterrolebinding
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/rbac"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+)
+
+// NewClusterRoleBindingFromKubeClusterRoleBinding will create a new
+// ClusterRoleBinding object with the data from a provided kubernetes
+// cluster role binding object of any supported api version
+func NewClusterRoleBindingFromKubeClusterRoleBinding(obj interface{}) (*ClusterRoleBinding, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(rbacv1.ClusterRoleBinding{}):
+		o := obj.(rbacv1.ClusterRoleBinding)
+		return fromKubeClusterRoleBindingV1(&o)
+	case reflect.TypeOf(&rbacv1.ClusterRoleBinding{}):
+		return fromKubeClusterRoleBindingV1(obj.(*rbacv1.ClusterRoleBinding))
+	case reflect.TypeOf(rbacv1beta1.ClusterRoleBinding{}):
+		o := obj.(rbacv1beta1.ClusterRoleBinding)
+		return fromKubeClusterRoleBindingViaV1(&o)
+	case reflect.TypeOf(&rbacv1beta1.ClusterRoleBinding{}):
+		return fromKubeClusterRoleBindingViaV1(obj.(*rbacv1beta1.ClusterRoleBinding))
+	case reflect.TypeOf(rbacv1alpha1.ClusterRoleBinding{}):
+		o := obj.(rbacv1alpha1.ClusterRoleBinding)
+		return fromKubeClusterRoleBindingViaV1(&o)
+	case reflect.TypeOf(&rbacv1alpha1.ClusterRoleBinding{}):
+		return fromKubeClusterRoleBindingViaV1(obj.(*rbacv1alpha1.ClusterRoleBinding))
+	default:
+		return nil, fmt.Errorf("unknown ClusterRoleBinding version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeClusterRoleBindingV1(kubeClusterRoleBinding *rbacv1.ClusterRoleBinding) (*ClusterRoleBinding, error) {
+	subjects, err := rbac.NewSubjectsFromKubeSubjectsV1(kubeClusterRoleBinding.Subjects)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.subjects")
+	}
+
+	roleRef, err := rbac.NewRoleRefFromKubeRoleRefV1(kubeClusterRoleBinding.RoleRef)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.roleRef")
+	}
+
+	return &ClusterRoleBinding{
+		Name:        kubeClusterRoleBinding.Name,
+		Namespace:   kubeClusterRoleBinding.Namespace,
+		Version:     kubeClusterRoleBinding.APIVersion,
+		Cluster:     kubeClusterRoleBinding.ClusterName,
+		Labels:      kubeClusterRoleBinding.Labels,
+		Annotations: kubeClusterRoleBinding.Annotations,
+		Subjects:    subjects,
+		RoleRef:     *roleRef,
+	}, nil
+}
+
+// fromKubeClusterRoleBindingViaV1 converts a cluster role binding of an
+// older api version, which has the same fields as rbac v1.  The api
+// version of the original is kept.
+func fromKubeClusterRoleBindingViaV1(kubeClusterRoleBinding interface{}) (*ClusterRoleBinding, error) {
+	v1ClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	err := converterutils.ConvertKubeObject(kubeClusterRoleBinding, v1ClusterRoleBinding)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeClusterRoleBinding, "couldn't convert to %s: %s", rbacv1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeClusterRoleBindingV1(v1ClusterRoleBinding)
+}
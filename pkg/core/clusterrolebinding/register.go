@@ -0,0 +1,34 @@
+package clusterrolebinding
+
+import (
+	"mantle/pkg/registry"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "cluster_role_binding"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"),
+			rbacv1alpha1.SchemeGroupVersion.WithKind("ClusterRoleBinding"),
+			rbacv1beta1.SchemeGroupVersion.WithKind("ClusterRoleBinding"),
+		},
+		New: func() registry.Object {
+			return &ClusterRoleBinding{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			b, err := NewClusterRoleBindingFromKubeClusterRoleBinding(obj)
+			if err != nil {
+				return nil, err
+			}
+			return b, nil
+		},
+	})
+}
@@ -0,0 +1,98 @@
+package clusterrolebinding
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/rbac"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes cluster role binding object of the api
+// version type defined in the object
+func (b *ClusterRoleBinding) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(b.Version) {
+	case "rbac.authorization.k8s.io/v1":
+		return b.toKubeV1()
+	case "":
+		return b.toKubeV1()
+	case "rbac.authorization.k8s.io/v1beta1":
+		return b.toKubeV1beta1()
+	case "rbac.authorization.k8s.io/v1alpha1":
+		return b.toKubeV1alpha1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for cluster role binding: %s", b.Version)
+	}
+}
+
+func (b *ClusterRoleBinding) toKubeV1() (*rbacv1.ClusterRoleBinding, error) {
+	subjects, err := rbac.NewKubeSubjectsV1(b.Subjects)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.subjects")
+	}
+
+	roleRef, err := rbac.NewKubeRoleRefV1(b.RoleRef)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.roleRef")
+	}
+
+	kubeClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	kubeClusterRoleBinding.Name = b.Name
+	kubeClusterRoleBinding.Namespace = b.Namespace
+	kubeClusterRoleBinding.APIVersion = "rbac.authorization.k8s.io/v1"
+	kubeClusterRoleBinding.ClusterName = b.Cluster
+	kubeClusterRoleBinding.Kind = "ClusterRoleBinding"
+	kubeClusterRoleBinding.Labels = b.Labels
+	kubeClusterRoleBinding.Annotations = b.Annotations
+	kubeClusterRoleBinding.Subjects = subjects
+	kubeClusterRoleBinding.RoleRef = roleRef
+
+	return kubeClusterRoleBinding, nil
+}
+
+func (b *ClusterRoleBinding) toKubeV1beta1() (*rbacv1beta1.ClusterRoleBinding, error) {
+	kubeClusterRoleBinding := &rbacv1beta1.ClusterRoleBinding{}
+	err := b.toKubeViaV1(kubeClusterRoleBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClusterRoleBinding.SetGroupVersionKind(rbacv1beta1.SchemeGroupVersion.WithKind("ClusterRoleBinding"))
+	return kubeClusterRoleBinding, nil
+}
+
+func (b *ClusterRoleBinding) toKubeV1alpha1() (*rbacv1alpha1.ClusterRoleBinding, error) {
+	kubeClusterRoleBinding := &rbacv1alpha1.ClusterRoleBinding{}
+	err := b.toKubeViaV1(kubeClusterRoleBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	rbac.SetKubeSubjectsAPIVersionV1alpha1(kubeClusterRoleBinding.Subjects)
+	kubeClusterRoleBinding.SetGroupVersionKind(rbacv1alpha1.SchemeGroupVersion.WithKind("ClusterRoleBinding"))
+	return kubeClusterRoleBinding, nil
+}
+
+// toKubeViaV1 converts the cluster role binding to rbac v1 and from there
+// into kubeClusterRoleBinding, a cluster role binding of an older api
+// version
+func (b *ClusterRoleBinding) toKubeViaV1(kubeClusterRoleBinding interface{}) error {
+	v1ClusterRoleBinding, err := b.toKubeV1()
+	if err != nil {
+		return err
+	}
+
+	err = converterutils.ConvertKubeObject(v1ClusterRoleBinding, kubeClusterRoleBinding)
+	if err != nil {
+		return serrors.InvalidInstanceErrorf(b, "couldn't convert to %s: %s", b.Version, err)
+	}
+
+	return nil
+}
@@ -13,15 +13,15 @@ func NewConfigMapFromKubeConfigMap(cm interface{}) (*ConfigMap, error) {
 	switch reflect.TypeOf(cm) {
 	case reflect.TypeOf(v1.ConfigMap{}):
 		obj := cm.(v1.ConfigMap)
-		return fromKubeConfigMapV1(&obj)
+		return fromKubeV1(&obj)
 	case reflect.TypeOf(&v1.ConfigMap{}):
-		return fromKubeConfigMapV1(cm.(*v1.ConfigMap))
+		return fromKubeV1(cm.(*v1.ConfigMap))
 	default:
 		return nil, fmt.Errorf("unknown ConfigMap version: %s", reflect.TypeOf(cm))
 	}
 }
 
-func fromKubeConfigMapV1(kubeConfigMap *v1.ConfigMap) (*ConfigMap, error) {
+func fromKubeV1(kubeConfigMap *v1.ConfigMap) (*ConfigMap, error) {
 	cm := &ConfigMap{
 		Name:        kubeConfigMap.Name,
 		Namespace:   kubeConfigMap.Namespace,
@@ -0,0 +1,30 @@
+package configmap
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "config_map"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		},
+		New: func() registry.Object {
+			return &ConfigMap{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			cm, err := NewConfigMapFromKubeConfigMap(obj)
+			if err != nil {
+				return nil, err
+			}
+			return cm, nil
+		},
+	})
+}
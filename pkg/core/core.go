@@ -3,6 +3,31 @@ package core
 import (
 	//	_ "github.com/koki/mantle/pkg/core/pod"
 	//	_ "github.com/koki/mantle/pkg/core/port"
+	_ "mantle/pkg/core/clusterrole"
+	_ "mantle/pkg/core/clusterrolebinding"
 	_ "mantle/pkg/core/configmap"
+	_ "mantle/pkg/core/cronjob"
+	_ "mantle/pkg/core/daemonset"
+	_ "mantle/pkg/core/deployment"
+	_ "mantle/pkg/core/horizontalpodautoscaler"
+	_ "mantle/pkg/core/ingress"
+	_ "mantle/pkg/core/job"
+	_ "mantle/pkg/core/limitrange"
+	_ "mantle/pkg/core/namespace"
+	_ "mantle/pkg/core/networkpolicy"
+	_ "mantle/pkg/core/persistentvolume"
 	_ "mantle/pkg/core/pod"
+	_ "mantle/pkg/core/poddisruptionbudget"
+	_ "mantle/pkg/core/priorityclass"
+	_ "mantle/pkg/core/pvc"
+	_ "mantle/pkg/core/replicaset"
+	_ "mantle/pkg/core/replicationcontroller"
+	_ "mantle/pkg/core/resourcequota"
+	_ "mantle/pkg/core/role"
+	_ "mantle/pkg/core/rolebinding"
+	_ "mantle/pkg/core/secret"
+	_ "mantle/pkg/core/service"
+	_ "mantle/pkg/core/serviceaccount"
+	_ "mantle/pkg/core/statefulset"
+	_ "mantle/pkg/core/storageclass"
 )
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"mantle/internal/yaml"
+	"mantle/pkg/registry"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TestErrorPaths checks that conversion errors in either direction carry
+// the path of the offending field, so that they can be located in the
+// source document.  Mantle documents are converted with ToKube and
+// kubernetes objects with FromKube.
+func TestErrorPaths(t *testing.T) {
+	testcases := []struct {
+		description string
+		mantleType  string
+		mantle      string
+		kube        runtime.Object
+		expected    string
+	}{
+		{
+			description: "pod container port",
+			mantleType:  "pod",
+			mantle:      "containers:\n- image: nginx\n  ports:\n  - http: 80/udp/tcp\n",
+			expected:    "containers.0.ports.0.http",
+		},
+		{
+			description: "pod restart policy",
+			mantleType:  "pod",
+			mantle:      "restartPolicy: sometimes\n",
+			expected:    "restartPolicy",
+		},
+		{
+			description: "pod volume",
+			mantleType:  "pod",
+			mantle:      "volumes:\n- data:pvc:claim\n- cache:bogus\n",
+			expected:    "volumes.1",
+		},
+		{
+			description: "deployment strategy",
+			mantleType:  "deployment",
+			mantle:      "strategy: blue-green\n",
+			expected:    "strategy",
+		},
+		{
+			description: "stateful set claim template",
+			mantleType:  "stateful_set",
+			mantle:      "volumeClaimTemplates:\n- data:10Gi\n- conf:ten\n",
+			expected:    "volumeClaimTemplates.1",
+		},
+		{
+			description: "ingress rule",
+			mantleType:  "ingress",
+			mantle:      "rules:\n- example.com/api: api-svc\n",
+			expected:    "rules.0.example.com/api",
+		},
+		{
+			description: "limit range limit",
+			mantleType:  "limit_range",
+			mantle:      "limits:\n- container:\n  - cpu ..2\n  - memory 1..x\n",
+			expected:    "limits.0.container.1",
+		},
+		{
+			description: "kubernetes pod container port",
+			mantleType:  "pod",
+			kube: &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "app", Ports: []v1.ContainerPort{{ContainerPort: 80, Protocol: "SCTP"}}},
+			}}},
+			expected: "spec.containers.0.ports.0",
+		},
+		{
+			description: "kubernetes deployment restart policy",
+			mantleType:  "deployment",
+			kube: &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{RestartPolicy: "Sometimes"}},
+			}},
+			expected: "spec.template.spec.restartPolicy",
+		},
+		{
+			description: "kubernetes stateful set duplicate claim",
+			mantleType:  "stateful_set",
+			kube: &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []v1.PersistentVolumeClaim{{}, {}},
+			}},
+			expected: "spec.volumeClaimTemplates.1",
+		},
+		{
+			description: "kubernetes cluster role rule",
+			mantleType:  "cluster_role",
+			kube: &rbacv1.ClusterRole{Rules: []rbacv1.PolicyRule{
+				{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+				{Verbs: []string{"get"}, NonResourceURLs: []string{"healthz"}},
+			}},
+			expected: "rules.1",
+		},
+	}
+
+	for _, tc := range testcases {
+		converter, ok := registry.ForName(tc.mantleType)
+		if !ok {
+			t.Errorf("%s: no converter for %s", tc.description, tc.mantleType)
+			continue
+		}
+
+		var err error
+		if tc.kube != nil {
+			_, err = converter.FromKube(tc.kube)
+		} else {
+			obj := converter.New()
+			err = yaml.UnmarshalSource(&yaml.Source{Data: []byte(tc.mantle)}, obj)
+			if err == nil {
+				_, err = obj.ToKube()
+			}
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.description)
+			continue
+		}
+
+		if path := strings.Join(yaml.ErrorPath(err), "."); path != tc.expected {
+			t.Errorf("%s: expected error path %s, got %s (%v)", tc.description, tc.expected, path, err)
+		}
+	}
+}
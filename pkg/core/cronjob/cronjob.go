@@ -0,0 +1,34 @@
+package cronjob
+
+import (
+	"mantle/pkg/core/job"
+)
+
+// CronJob defines a cron job object
+type CronJob struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Schedule is a cron expression, e.g. "*/5 * * * *" or "@daily"
+	Schedule                   string            `json:"schedule"`
+	StartingDeadline           *int64            `json:"startingDeadline,omitempty"`
+	Concurrency                ConcurrencyPolicy `json:"concurrency,omitempty"`
+	Suspend                    *bool             `json:"suspend,omitempty"`
+	SuccessfulJobsHistoryLimit *int32            `json:"successfulJobsHistoryLimit,omitempty"`
+	FailedJobsHistoryLimit     *int32            `json:"failedJobsHistoryLimit,omitempty"`
+
+	JobTemplate job.JobTemplate `json:"jobTemplate"`
+}
+
+type ConcurrencyPolicy string
+
+const (
+	ConcurrencyUnset   ConcurrencyPolicy = ""
+	ConcurrencyAllow   ConcurrencyPolicy = "allow"
+	ConcurrencyForbid  ConcurrencyPolicy = "forbid"
+	ConcurrencyReplace ConcurrencyPolicy = "replace"
+)
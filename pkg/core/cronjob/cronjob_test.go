@@ -0,0 +1,122 @@
+package cronjob
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/internal/converterutils"
+
+	"github.com/koki/json"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	batchv2alpha1 "k8s.io/api/batch/v2alpha1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func testKubeCronJob() *batchv1beta1.CronJob {
+	return &batchv1beta1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1beta1",
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "testNS",
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                   "0 3 * * *",
+			ConcurrencyPolicy:          batchv1beta1.ForbidConcurrent,
+			Suspend:                    boolPtr(false),
+			SuccessfulJobsHistoryLimit: int32Ptr(3),
+			FailedJobsHistoryLimit:     int32Ptr(1),
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "backup"},
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: int32Ptr(2),
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{
+							Containers:    []v1.Container{{Name: "backup", Image: "backup"}},
+							RestartPolicy: v1.RestartPolicyNever,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCronJobRoundTrip(t *testing.T) {
+	testcases := []struct {
+		version string
+		obj     runtime.Object
+	}{
+		{
+			version: "batch/v1beta1",
+			obj:     &batchv1beta1.CronJob{},
+		},
+		{
+			version: "batch/v2alpha1",
+			obj:     &batchv2alpha1.CronJob{},
+		},
+	}
+
+	for _, tc := range testcases {
+		err := converterutils.ConvertKubeObject(testKubeCronJob(), tc.obj)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.version, err)
+		}
+		tc.obj.GetObjectKind().SetGroupVersionKind(schema.FromAPIVersionAndKind(tc.version, "CronJob"))
+
+		c, err := NewCronJobFromKubeCronJob(tc.obj)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", tc.version, err)
+		}
+		if c.Version != tc.version {
+			t.Errorf("%s: wrong version %s", tc.version, c.Version)
+		}
+
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", tc.version, err)
+		}
+		c = &CronJob{}
+		if err := json.Unmarshal(data, c); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", tc.version, data, err)
+		}
+
+		obj, err := c.ToKube()
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", tc.version, err)
+		}
+		if !reflect.DeepEqual(obj, tc.obj) {
+			t.Errorf("%s: round trip changed the cron job\nexpected %#v\ngot      %#v", tc.version, tc.obj, obj)
+		}
+	}
+}
+
+func TestCronJobInvalidSchedule(t *testing.T) {
+	kubeCronJob := testKubeCronJob()
+	kubeCronJob.Spec.Schedule = "0 25 * * *"
+	if _, err := NewCronJobFromKubeCronJob(kubeCronJob); err == nil {
+		t.Errorf("expected invalid schedule to be rejected when converting from kube")
+	}
+
+	c := &CronJob{Schedule: "every day"}
+	if _, err := c.ToKube(); err == nil {
+		t.Errorf("expected invalid schedule to be rejected when converting to kube")
+	}
+}
@@ -0,0 +1,95 @@
+package cronjob
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/job"
+
+	serrors "github.com/koki/structurederrors"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	batchv2alpha1 "k8s.io/api/batch/v2alpha1"
+)
+
+// NewCronJobFromKubeCronJob will create a new CronJob object with the
+// data from a provided kubernetes cron job object of any supported api
+// version
+func NewCronJobFromKubeCronJob(obj interface{}) (*CronJob, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(batchv1beta1.CronJob{}):
+		o := obj.(batchv1beta1.CronJob)
+		return fromKubeCronJobV1beta1(&o)
+	case reflect.TypeOf(&batchv1beta1.CronJob{}):
+		return fromKubeCronJobV1beta1(obj.(*batchv1beta1.CronJob))
+	case reflect.TypeOf(batchv2alpha1.CronJob{}):
+		o := obj.(batchv2alpha1.CronJob)
+		return fromKubeCronJobViaV1beta1(&o)
+	case reflect.TypeOf(&batchv2alpha1.CronJob{}):
+		return fromKubeCronJobViaV1beta1(obj.(*batchv2alpha1.CronJob))
+	default:
+		return nil, fmt.Errorf("unknown CronJob version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeCronJobV1beta1(kubeCronJob *batchv1beta1.CronJob) (*CronJob, error) {
+	err := converterutils.ValidateCronSchedule(kubeCronJob.Spec.Schedule)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.schedule")
+	}
+
+	concurrency, err := fromKubeConcurrencyPolicyV1beta1(kubeCronJob.Spec.ConcurrencyPolicy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.concurrencyPolicy")
+	}
+
+	jobTemplate, err := job.NewJobTemplateFromKubeJobTemplateSpec(&kubeCronJob.Spec.JobTemplate)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.jobTemplate")
+	}
+
+	return &CronJob{
+		Name:                       kubeCronJob.Name,
+		Namespace:                  kubeCronJob.Namespace,
+		Version:                    kubeCronJob.APIVersion,
+		Cluster:                    kubeCronJob.ClusterName,
+		Labels:                     kubeCronJob.Labels,
+		Annotations:                kubeCronJob.Annotations,
+		Schedule:                   kubeCronJob.Spec.Schedule,
+		StartingDeadline:           kubeCronJob.Spec.StartingDeadlineSeconds,
+		Concurrency:                concurrency,
+		Suspend:                    kubeCronJob.Spec.Suspend,
+		SuccessfulJobsHistoryLimit: kubeCronJob.Spec.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     kubeCronJob.Spec.FailedJobsHistoryLimit,
+		JobTemplate:                *jobTemplate,
+	}, nil
+}
+
+// fromKubeCronJobViaV1beta1 converts a cron job of an older api version,
+// which has the same fields as batch/v1beta1.  The api version of the
+// original is kept.
+func fromKubeCronJobViaV1beta1(kubeCronJob interface{}) (*CronJob, error) {
+	v1beta1CronJob := &batchv1beta1.CronJob{}
+	err := converterutils.ConvertKubeObject(kubeCronJob, v1beta1CronJob)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeCronJob, "couldn't convert to %s: %s", batchv1beta1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeCronJobV1beta1(v1beta1CronJob)
+}
+
+func fromKubeConcurrencyPolicyV1beta1(kubePolicy batchv1beta1.ConcurrencyPolicy) (ConcurrencyPolicy, error) {
+	switch kubePolicy {
+	case "":
+		return ConcurrencyUnset, nil
+	case batchv1beta1.AllowConcurrent:
+		return ConcurrencyAllow, nil
+	case batchv1beta1.ForbidConcurrent:
+		return ConcurrencyForbid, nil
+	case batchv1beta1.ReplaceConcurrent:
+		return ConcurrencyReplace, nil
+	default:
+		return ConcurrencyUnset, serrors.InvalidValueErrorf(kubePolicy, "unrecognized concurrency policy")
+	}
+}
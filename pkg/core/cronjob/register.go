@@ -0,0 +1,32 @@
+package cronjob
+
+import (
+	"mantle/pkg/registry"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	batchv2alpha1 "k8s.io/api/batch/v2alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "cron_job"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			batchv1beta1.SchemeGroupVersion.WithKind("CronJob"),
+			batchv2alpha1.SchemeGroupVersion.WithKind("CronJob"),
+		},
+		New: func() registry.Object {
+			return &CronJob{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			c, err := NewCronJobFromKubeCronJob(obj)
+			if err != nil {
+				return nil, err
+			}
+			return c, nil
+		},
+	})
+}
@@ -0,0 +1,97 @@
+package cronjob
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+
+	serrors "github.com/koki/structurederrors"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	batchv2alpha1 "k8s.io/api/batch/v2alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes cron job object of the api version type
+// defined in the object
+func (c *CronJob) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(c.Version) {
+	case "batch/v1beta1":
+		return c.toKubeV1beta1()
+	case "":
+		return c.toKubeV1beta1()
+	case "batch/v2alpha1":
+		return c.toKubeV2alpha1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for cron job: %s", c.Version)
+	}
+}
+
+func (c *CronJob) toKubeV1beta1() (*batchv1beta1.CronJob, error) {
+	err := converterutils.ValidateCronSchedule(c.Schedule)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.schedule")
+	}
+
+	concurrency, err := c.toKubeV1beta1ConcurrencyPolicy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.concurrency")
+	}
+
+	jobTemplate, err := c.JobTemplate.ToKube("batch/v1beta1")
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.jobTemplate")
+	}
+
+	kubeCronJob := &batchv1beta1.CronJob{}
+	kubeCronJob.Name = c.Name
+	kubeCronJob.Namespace = c.Namespace
+	kubeCronJob.APIVersion = "batch/v1beta1"
+	kubeCronJob.ClusterName = c.Cluster
+	kubeCronJob.Kind = "CronJob"
+	kubeCronJob.Labels = c.Labels
+	kubeCronJob.Annotations = c.Annotations
+	kubeCronJob.Spec = batchv1beta1.CronJobSpec{
+		Schedule:                   c.Schedule,
+		StartingDeadlineSeconds:    c.StartingDeadline,
+		ConcurrencyPolicy:          concurrency,
+		Suspend:                    c.Suspend,
+		JobTemplate:                *jobTemplate.(*batchv1beta1.JobTemplateSpec),
+		SuccessfulJobsHistoryLimit: c.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     c.FailedJobsHistoryLimit,
+	}
+
+	return kubeCronJob, nil
+}
+
+func (c *CronJob) toKubeV2alpha1() (*batchv2alpha1.CronJob, error) {
+	v1beta1CronJob, err := c.toKubeV1beta1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeCronJob := &batchv2alpha1.CronJob{}
+	err = converterutils.ConvertKubeObject(v1beta1CronJob, kubeCronJob)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(c, "couldn't convert to %s: %s", c.Version, err)
+	}
+
+	kubeCronJob.SetGroupVersionKind(batchv2alpha1.SchemeGroupVersion.WithKind("CronJob"))
+	return kubeCronJob, nil
+}
+
+func (c *CronJob) toKubeV1beta1ConcurrencyPolicy() (batchv1beta1.ConcurrencyPolicy, error) {
+	switch c.Concurrency {
+	case ConcurrencyUnset:
+		return "", nil
+	case ConcurrencyAllow:
+		return batchv1beta1.AllowConcurrent, nil
+	case ConcurrencyForbid:
+		return batchv1beta1.ForbidConcurrent, nil
+	case ConcurrencyReplace:
+		return batchv1beta1.ReplaceConcurrent, nil
+	default:
+		return "", serrors.InvalidValueErrorf(c.Concurrency, "unrecognized concurrency policy")
+	}
+}
@@ -0,0 +1,83 @@
+package daemonset
+
+import (
+	"strings"
+
+	"mantle/internal/converterutils"
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DaemonSet defines a daemon set object
+type DaemonSet struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Selector             *selector.LabelSelector `json:"selector,omitempty"`
+	UpdateStrategy       *UpdateStrategy         `json:"updateStrategy,omitempty"`
+	MinReady             int32                   `json:"minReady,omitempty"`
+	RevisionHistoryLimit *int32                  `json:"revisionHistoryLimit,omitempty"`
+	// TemplateGeneration is only supported by extensions/v1beta1
+	TemplateGeneration int64 `json:"templateGeneration,omitempty"`
+
+	Template pod.PodTemplate `json:"template"`
+}
+
+type UpdateStrategyType string
+
+const (
+	UpdateStrategyTypeOnDelete UpdateStrategyType = "on-delete"
+	UpdateStrategyTypeRolling  UpdateStrategyType = "rolling"
+)
+
+// UpdateStrategy is how a daemon set replaces its pods.  It is written as
+// "on-delete", "rolling" or "rolling:maxUnavailable", e.g. "rolling:10%".
+type UpdateStrategy struct {
+	Type           UpdateStrategyType
+	MaxUnavailable *intstr.IntOrString
+}
+
+func (s *UpdateStrategy) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form %s or %s:maxUnavailable", UpdateStrategyTypeOnDelete, UpdateStrategyTypeRolling)
+	}
+
+	segments := strings.Split(str, ":")
+	s.Type = UpdateStrategyType(segments[0])
+	switch s.Type {
+	case UpdateStrategyTypeOnDelete:
+		if len(segments) > 1 {
+			return serrors.InvalidValueErrorf(str, "%s doesn't take any parameters", UpdateStrategyTypeOnDelete)
+		}
+	case UpdateStrategyTypeRolling:
+		if len(segments) > 2 {
+			return serrors.InvalidValueErrorf(str, "expected %s:maxUnavailable", UpdateStrategyTypeRolling)
+		}
+		if len(segments) > 1 {
+			s.MaxUnavailable = converterutils.ParseIntOrStringPtr(segments[1])
+		}
+	default:
+		return serrors.InvalidValueErrorf(str, "unsupported update strategy, expected %s or %s", UpdateStrategyTypeOnDelete, UpdateStrategyTypeRolling)
+	}
+
+	return nil
+}
+
+func (s UpdateStrategy) MarshalJSON() ([]byte, error) {
+	if s.MaxUnavailable == nil {
+		return json.Marshal(s.Type)
+	}
+
+	return json.Marshal(string(s.Type) + ":" + converterutils.FormatIntOrStringPtr(s.MaxUnavailable))
+}
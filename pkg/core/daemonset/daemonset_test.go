@@ -0,0 +1,159 @@
+package daemonset
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/internal/converterutils"
+
+	"github.com/koki/json"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func testKubeDaemonSet() *appsv1.DaemonSet {
+	maxUnavailable := intstr.FromString("10%")
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "DaemonSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fluentd",
+			Namespace: "kube-system",
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "fluentd"},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "fluentd"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "fluentd", Image: "fluentd"}},
+				},
+			},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			MinReadySeconds:      5,
+			RevisionHistoryLimit: int32Ptr(10),
+		},
+	}
+}
+
+func TestDaemonSetRoundTrip(t *testing.T) {
+	testcases := []struct {
+		version string
+		obj     runtime.Object
+	}{
+		{
+			version: "apps/v1",
+			obj:     &appsv1.DaemonSet{},
+		},
+		{
+			version: "apps/v1beta2",
+			obj:     &appsv1beta2.DaemonSet{},
+		},
+		{
+			version: "extensions/v1beta1",
+			obj:     &extensionsv1beta1.DaemonSet{},
+		},
+	}
+
+	for _, tc := range testcases {
+		err := converterutils.ConvertKubeObject(testKubeDaemonSet(), tc.obj)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.version, err)
+		}
+		tc.obj.GetObjectKind().SetGroupVersionKind(schema.FromAPIVersionAndKind(tc.version, "DaemonSet"))
+		if ds, ok := tc.obj.(*extensionsv1beta1.DaemonSet); ok {
+			ds.Spec.TemplateGeneration = 3
+		}
+
+		d, err := NewDaemonSetFromKubeDaemonSet(tc.obj)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", tc.version, err)
+		}
+		if d.Version != tc.version {
+			t.Errorf("%s: wrong version %s", tc.version, d.Version)
+		}
+
+		data, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", tc.version, err)
+		}
+		d = &DaemonSet{}
+		if err := json.Unmarshal(data, d); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", tc.version, data, err)
+		}
+
+		obj, err := d.ToKube()
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", tc.version, err)
+		}
+		if !reflect.DeepEqual(obj, tc.obj) {
+			t.Errorf("%s: round trip changed the daemon set\nexpected %#v\ngot      %#v", tc.version, tc.obj, obj)
+		}
+	}
+}
+
+func TestDaemonSetTemplateGeneration(t *testing.T) {
+	testcases := []struct {
+		version string
+		pass    bool
+	}{
+		{version: "extensions/v1beta1", pass: true},
+		{version: "apps/v1beta2", pass: false},
+		{version: "", pass: false},
+	}
+
+	for _, tc := range testcases {
+		d := DaemonSet{
+			Version:            tc.version,
+			TemplateGeneration: 2,
+		}
+		_, err := d.ToKube()
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.version, err)
+		}
+	}
+}
+
+func TestUpdateStrategyShorthand(t *testing.T) {
+	testcases := []struct {
+		str  string
+		pass bool
+	}{
+		{str: "on-delete", pass: true},
+		{str: "rolling", pass: true},
+		{str: "rolling:10%", pass: true},
+		{str: "rolling:1", pass: true},
+		{str: "on-delete:1", pass: false},
+		{str: "rolling:1:2", pass: false},
+		{str: "recreate", pass: false},
+	}
+
+	for _, tc := range testcases {
+		strategy := UpdateStrategy{}
+		err := json.Unmarshal([]byte(`"`+tc.str+`"`), &strategy)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+		}
+	}
+}
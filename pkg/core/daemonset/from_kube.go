@@ -0,0 +1,114 @@
+package daemonset
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+)
+
+// NewDaemonSetFromKubeDaemonSet will create a new DaemonSet object with
+// the data from a provided kubernetes daemon set object of any supported
+// api version
+func NewDaemonSetFromKubeDaemonSet(obj interface{}) (*DaemonSet, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(appsv1.DaemonSet{}):
+		o := obj.(appsv1.DaemonSet)
+		return fromKubeDaemonSetV1(&o)
+	case reflect.TypeOf(&appsv1.DaemonSet{}):
+		return fromKubeDaemonSetV1(obj.(*appsv1.DaemonSet))
+	case reflect.TypeOf(appsv1beta2.DaemonSet{}):
+		o := obj.(appsv1beta2.DaemonSet)
+		return fromKubeDaemonSetViaV1(&o)
+	case reflect.TypeOf(&appsv1beta2.DaemonSet{}):
+		return fromKubeDaemonSetViaV1(obj.(*appsv1beta2.DaemonSet))
+	case reflect.TypeOf(extensionsv1beta1.DaemonSet{}):
+		o := obj.(extensionsv1beta1.DaemonSet)
+		return fromKubeDaemonSetExtensionsV1beta1(&o)
+	case reflect.TypeOf(&extensionsv1beta1.DaemonSet{}):
+		return fromKubeDaemonSetExtensionsV1beta1(obj.(*extensionsv1beta1.DaemonSet))
+	default:
+		return nil, fmt.Errorf("unknown DaemonSet version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeDaemonSetV1(kubeDaemonSet *appsv1.DaemonSet) (*DaemonSet, error) {
+	template, err := pod.NewPodTemplateFromKubePodTemplateSpec(&kubeDaemonSet.Spec.Template)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.template")
+	}
+
+	updateStrategy, err := fromKubeUpdateStrategyV1(&kubeDaemonSet.Spec.UpdateStrategy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.updateStrategy")
+	}
+
+	return &DaemonSet{
+		Name:                 kubeDaemonSet.Name,
+		Namespace:            kubeDaemonSet.Namespace,
+		Version:              kubeDaemonSet.APIVersion,
+		Cluster:              kubeDaemonSet.ClusterName,
+		Labels:               kubeDaemonSet.Labels,
+		Annotations:          kubeDaemonSet.Annotations,
+		Selector:             selector.NewLabelSelectorFromKubeLabelSelectorV1(kubeDaemonSet.Spec.Selector),
+		UpdateStrategy:       updateStrategy,
+		MinReady:             kubeDaemonSet.Spec.MinReadySeconds,
+		RevisionHistoryLimit: kubeDaemonSet.Spec.RevisionHistoryLimit,
+		Template:             *template,
+	}, nil
+}
+
+func fromKubeDaemonSetExtensionsV1beta1(kubeDaemonSet *extensionsv1beta1.DaemonSet) (*DaemonSet, error) {
+	d, err := fromKubeDaemonSetViaV1(kubeDaemonSet)
+	if err != nil {
+		return nil, err
+	}
+
+	d.TemplateGeneration = kubeDaemonSet.Spec.TemplateGeneration
+	return d, nil
+}
+
+// fromKubeDaemonSetViaV1 converts a daemon set of an older api version,
+// which has the same fields as apps/v1 except for templateGeneration.  The
+// api version of the original is kept.
+func fromKubeDaemonSetViaV1(kubeDaemonSet interface{}) (*DaemonSet, error) {
+	v1DaemonSet := &appsv1.DaemonSet{}
+	err := converterutils.ConvertKubeObject(kubeDaemonSet, v1DaemonSet)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeDaemonSet, "couldn't convert to %s: %s", appsv1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeDaemonSetV1(v1DaemonSet)
+}
+
+func fromKubeUpdateStrategyV1(kubeStrategy *appsv1.DaemonSetUpdateStrategy) (*UpdateStrategy, error) {
+	if len(kubeStrategy.Type) == 0 && kubeStrategy.RollingUpdate == nil {
+		return nil, nil
+	}
+
+	strategy := &UpdateStrategy{}
+	switch kubeStrategy.Type {
+	case appsv1.OnDeleteDaemonSetStrategyType:
+		strategy.Type = UpdateStrategyTypeOnDelete
+		if kubeStrategy.RollingUpdate != nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeStrategy, "rollingUpdate can't be set for the %s strategy", appsv1.OnDeleteDaemonSetStrategyType)
+		}
+	case appsv1.RollingUpdateDaemonSetStrategyType, "":
+		strategy.Type = UpdateStrategyTypeRolling
+		if kubeStrategy.RollingUpdate != nil {
+			strategy.MaxUnavailable = kubeStrategy.RollingUpdate.MaxUnavailable
+		}
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeStrategy.Type, "unrecognized daemon set update strategy")
+	}
+
+	return strategy, nil
+}
@@ -0,0 +1,34 @@
+package daemonset
+
+import (
+	"mantle/pkg/registry"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "daemon_set"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			appsv1.SchemeGroupVersion.WithKind("DaemonSet"),
+			appsv1beta2.SchemeGroupVersion.WithKind("DaemonSet"),
+			extensionsv1beta1.SchemeGroupVersion.WithKind("DaemonSet"),
+		},
+		New: func() registry.Object {
+			return &DaemonSet{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			d, err := NewDaemonSetFromKubeDaemonSet(obj)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		},
+	})
+}
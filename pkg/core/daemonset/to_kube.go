@@ -0,0 +1,134 @@
+package daemonset
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes daemon set object of the api version
+// type defined in the object
+func (d *DaemonSet) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(d.Version) {
+	case "apps/v1":
+		return d.toKubeV1()
+	case "":
+		return d.toKubeV1()
+	case "apps/v1beta2":
+		return d.toKubeV1beta2()
+	case "extensions/v1beta1":
+		return d.toKubeExtensionsV1beta1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for daemon set: %s", d.Version)
+	}
+}
+
+func (d *DaemonSet) toKubeV1() (*appsv1.DaemonSet, error) {
+	if d.TemplateGeneration != 0 && strings.ToLower(d.Version) != "extensions/v1beta1" {
+		return nil, serrors.InvalidInstanceErrorf(d, "templateGeneration isn't supported by %s", d.Version)
+	}
+
+	template, err := d.Template.ToKube("v1")
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.template")
+	}
+
+	updateStrategy, err := d.toKubeV1UpdateStrategy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.updateStrategy")
+	}
+
+	kubeDaemonSet := &appsv1.DaemonSet{}
+	kubeDaemonSet.Name = d.Name
+	kubeDaemonSet.Namespace = d.Namespace
+	kubeDaemonSet.APIVersion = "apps/v1"
+	kubeDaemonSet.ClusterName = d.Cluster
+	kubeDaemonSet.Kind = "DaemonSet"
+	kubeDaemonSet.Labels = d.Labels
+	kubeDaemonSet.Annotations = d.Annotations
+	kubeDaemonSet.Spec = appsv1.DaemonSetSpec{
+		Selector:             d.Selector.ToKubeLabelSelectorV1(),
+		Template:             *template.(*v1.PodTemplateSpec),
+		UpdateStrategy:       updateStrategy,
+		MinReadySeconds:      d.MinReady,
+		RevisionHistoryLimit: d.RevisionHistoryLimit,
+	}
+
+	return kubeDaemonSet, nil
+}
+
+func (d *DaemonSet) toKubeV1beta2() (*appsv1beta2.DaemonSet, error) {
+	kubeDaemonSet := &appsv1beta2.DaemonSet{}
+	err := d.toKubeViaV1(kubeDaemonSet)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeDaemonSet.SetGroupVersionKind(appsv1beta2.SchemeGroupVersion.WithKind("DaemonSet"))
+	return kubeDaemonSet, nil
+}
+
+func (d *DaemonSet) toKubeExtensionsV1beta1() (*extensionsv1beta1.DaemonSet, error) {
+	kubeDaemonSet := &extensionsv1beta1.DaemonSet{}
+	err := d.toKubeViaV1(kubeDaemonSet)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeDaemonSet.SetGroupVersionKind(extensionsv1beta1.SchemeGroupVersion.WithKind("DaemonSet"))
+	kubeDaemonSet.Spec.TemplateGeneration = d.TemplateGeneration
+	return kubeDaemonSet, nil
+}
+
+// toKubeViaV1 converts the daemon set to apps/v1 and from there into
+// kubeDaemonSet, a daemon set of an older api version
+func (d *DaemonSet) toKubeViaV1(kubeDaemonSet interface{}) error {
+	v1DaemonSet, err := d.toKubeV1()
+	if err != nil {
+		return err
+	}
+
+	err = converterutils.ConvertKubeObject(v1DaemonSet, kubeDaemonSet)
+	if err != nil {
+		return serrors.InvalidInstanceErrorf(d, "couldn't convert to %s: %s", d.Version, err)
+	}
+
+	return nil
+}
+
+func (d *DaemonSet) toKubeV1UpdateStrategy() (appsv1.DaemonSetUpdateStrategy, error) {
+	if d.UpdateStrategy == nil {
+		return appsv1.DaemonSetUpdateStrategy{}, nil
+	}
+
+	switch d.UpdateStrategy.Type {
+	case UpdateStrategyTypeOnDelete:
+		if d.UpdateStrategy.MaxUnavailable != nil {
+			return appsv1.DaemonSetUpdateStrategy{}, serrors.InvalidInstanceErrorf(d.UpdateStrategy, "the %s strategy doesn't take any parameters", UpdateStrategyTypeOnDelete)
+		}
+		return appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.OnDeleteDaemonSetStrategyType,
+		}, nil
+	case UpdateStrategyTypeRolling:
+		strategy := appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.RollingUpdateDaemonSetStrategyType,
+		}
+		if d.UpdateStrategy.MaxUnavailable != nil {
+			strategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{
+				MaxUnavailable: d.UpdateStrategy.MaxUnavailable,
+			}
+		}
+		return strategy, nil
+	default:
+		return appsv1.DaemonSetUpdateStrategy{}, serrors.InvalidValueErrorf(d.UpdateStrategy.Type, "unrecognized daemon set update strategy")
+	}
+}
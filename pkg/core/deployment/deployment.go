@@ -0,0 +1,101 @@
+package deployment
+
+import (
+	"strings"
+
+	"mantle/internal/converterutils"
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Deployment defines a deployment object
+type Deployment struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Replicas             *int32                  `json:"replicas,omitempty"`
+	Selector             *selector.LabelSelector `json:"selector,omitempty"`
+	Strategy             *Strategy               `json:"strategy,omitempty"`
+	MinReady             int32                   `json:"minReady,omitempty"`
+	RevisionHistoryLimit *int32                  `json:"revisionHistoryLimit,omitempty"`
+	Paused               bool                    `json:"paused,omitempty"`
+	ProgressDeadline     *int32                  `json:"progressDeadline,omitempty"`
+	// RollbackTo is only supported by apps/v1beta1 and extensions/v1beta1
+	RollbackTo *int64 `json:"rollbackTo,omitempty"`
+
+	Template pod.PodTemplate `json:"template"`
+}
+
+type StrategyType string
+
+const (
+	StrategyTypeRecreate StrategyType = "recreate"
+	StrategyTypeRolling  StrategyType = "rolling"
+)
+
+// Strategy is how a deployment replaces old pods.  It is written as
+// "recreate", "rolling" or "rolling:maxUnavailable[:maxSurge]", e.g.
+// "rolling:25%:1".  Either bound can be left empty.
+type Strategy struct {
+	Type    StrategyType
+	Rolling *RollingUpdate
+}
+
+type RollingUpdate struct {
+	MaxUnavailable *intstr.IntOrString
+	MaxSurge       *intstr.IntOrString
+}
+
+func (s *Strategy) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form %s or %s:maxUnavailable:maxSurge", StrategyTypeRecreate, StrategyTypeRolling)
+	}
+
+	segments := strings.Split(str, ":")
+	s.Type = StrategyType(segments[0])
+	switch s.Type {
+	case StrategyTypeRecreate:
+		if len(segments) > 1 {
+			return serrors.InvalidValueErrorf(str, "%s doesn't take any parameters", StrategyTypeRecreate)
+		}
+	case StrategyTypeRolling:
+		if len(segments) > 3 {
+			return serrors.InvalidValueErrorf(str, "expected %s:maxUnavailable:maxSurge", StrategyTypeRolling)
+		}
+		if len(segments) > 1 {
+			s.Rolling = &RollingUpdate{
+				MaxUnavailable: converterutils.ParseIntOrStringPtr(segments[1]),
+			}
+		}
+		if len(segments) > 2 {
+			s.Rolling.MaxSurge = converterutils.ParseIntOrStringPtr(segments[2])
+		}
+	default:
+		return serrors.InvalidValueErrorf(str, "unsupported strategy, expected %s or %s", StrategyTypeRecreate, StrategyTypeRolling)
+	}
+
+	return nil
+}
+
+func (s Strategy) MarshalJSON() ([]byte, error) {
+	if s.Rolling == nil {
+		return json.Marshal(s.Type)
+	}
+
+	return json.Marshal(strings.Join([]string{
+		string(s.Type),
+		converterutils.FormatIntOrStringPtr(s.Rolling.MaxUnavailable),
+		converterutils.FormatIntOrStringPtr(s.Rolling.MaxSurge),
+	}, ":"))
+}
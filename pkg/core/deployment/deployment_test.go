@@ -0,0 +1,171 @@
+package deployment
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/internal/converterutils"
+
+	"github.com/koki/json"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func testKubeDeployment() *appsv1.Deployment {
+	maxUnavailable := intstr.FromString("25%")
+	maxSurge := intstr.FromInt(1)
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "testNS",
+			Labels:    map[string]string{"app": "web"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(3),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"cache", "db"}},
+				},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "web", "tier": "db"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "nginx", Image: "nginx"}},
+				},
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       &maxSurge,
+				},
+			},
+			MinReadySeconds:         5,
+			RevisionHistoryLimit:    int32Ptr(10),
+			ProgressDeadlineSeconds: int32Ptr(600),
+		},
+	}
+}
+
+func TestDeploymentRoundTrip(t *testing.T) {
+	testcases := []struct {
+		version string
+		obj     runtime.Object
+	}{
+		{
+			version: "apps/v1",
+			obj:     &appsv1.Deployment{},
+		},
+		{
+			version: "apps/v1beta1",
+			obj:     &appsv1beta1.Deployment{},
+		},
+		{
+			version: "apps/v1beta2",
+			obj:     &appsv1beta2.Deployment{},
+		},
+		{
+			version: "extensions/v1beta1",
+			obj:     &extensionsv1beta1.Deployment{},
+		},
+	}
+
+	for _, tc := range testcases {
+		err := converterutils.ConvertKubeObject(testKubeDeployment(), tc.obj)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.version, err)
+		}
+		tc.obj.GetObjectKind().SetGroupVersionKind(schema.FromAPIVersionAndKind(tc.version, "Deployment"))
+
+		d, err := NewDeploymentFromKubeDeployment(tc.obj)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", tc.version, err)
+		}
+		if d.Version != tc.version {
+			t.Errorf("%s: wrong version %s", tc.version, d.Version)
+		}
+
+		data, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", tc.version, err)
+		}
+		d = &Deployment{}
+		if err := json.Unmarshal(data, d); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", tc.version, data, err)
+		}
+
+		obj, err := d.ToKube()
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", tc.version, err)
+		}
+		if !reflect.DeepEqual(obj, tc.obj) {
+			t.Errorf("%s: round trip changed the deployment\nexpected %#v\ngot      %#v", tc.version, tc.obj, obj)
+		}
+	}
+}
+
+func TestDeploymentRollbackTo(t *testing.T) {
+	revision := int64(2)
+	testcases := []struct {
+		version string
+		pass    bool
+	}{
+		{version: "apps/v1beta1", pass: true},
+		{version: "extensions/v1beta1", pass: true},
+		{version: "apps/v1beta2", pass: false},
+		{version: "", pass: false},
+	}
+
+	for _, tc := range testcases {
+		d := Deployment{
+			Version:    tc.version,
+			RollbackTo: &revision,
+		}
+		_, err := d.ToKube()
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.version, err)
+		}
+	}
+}
+
+func TestStrategyShorthand(t *testing.T) {
+	testcases := []struct {
+		str  string
+		pass bool
+	}{
+		{str: "recreate", pass: true},
+		{str: "rolling", pass: true},
+		{str: "rolling:25%:1", pass: true},
+		{str: "rolling::1", pass: true},
+		{str: "recreate:1", pass: false},
+		{str: "rolling:1:2:3", pass: false},
+		{str: "blue-green", pass: false},
+	}
+
+	for _, tc := range testcases {
+		strategy := Strategy{}
+		err := json.Unmarshal([]byte(`"`+tc.str+`"`), &strategy)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+		}
+	}
+}
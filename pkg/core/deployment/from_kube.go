@@ -0,0 +1,146 @@
+package deployment
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+)
+
+// NewDeploymentFromKubeDeployment will create a new Deployment object with
+// the data from a provided kubernetes deployment object of any supported
+// api version
+func NewDeploymentFromKubeDeployment(obj interface{}) (*Deployment, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(appsv1.Deployment{}):
+		o := obj.(appsv1.Deployment)
+		return fromKubeDeploymentV1(&o)
+	case reflect.TypeOf(&appsv1.Deployment{}):
+		return fromKubeDeploymentV1(obj.(*appsv1.Deployment))
+	case reflect.TypeOf(appsv1beta1.Deployment{}):
+		o := obj.(appsv1beta1.Deployment)
+		return fromKubeDeploymentV1beta1(&o)
+	case reflect.TypeOf(&appsv1beta1.Deployment{}):
+		return fromKubeDeploymentV1beta1(obj.(*appsv1beta1.Deployment))
+	case reflect.TypeOf(appsv1beta2.Deployment{}):
+		o := obj.(appsv1beta2.Deployment)
+		return fromKubeDeploymentV1beta2(&o)
+	case reflect.TypeOf(&appsv1beta2.Deployment{}):
+		return fromKubeDeploymentV1beta2(obj.(*appsv1beta2.Deployment))
+	case reflect.TypeOf(extensionsv1beta1.Deployment{}):
+		o := obj.(extensionsv1beta1.Deployment)
+		return fromKubeDeploymentExtensionsV1beta1(&o)
+	case reflect.TypeOf(&extensionsv1beta1.Deployment{}):
+		return fromKubeDeploymentExtensionsV1beta1(obj.(*extensionsv1beta1.Deployment))
+	default:
+		return nil, fmt.Errorf("unknown Deployment version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeDeploymentV1(kubeDeployment *appsv1.Deployment) (*Deployment, error) {
+	template, err := pod.NewPodTemplateFromKubePodTemplateSpec(&kubeDeployment.Spec.Template)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.template")
+	}
+
+	strategy, err := fromKubeDeploymentStrategyV1(&kubeDeployment.Spec.Strategy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.strategy")
+	}
+
+	return &Deployment{
+		Name:                 kubeDeployment.Name,
+		Namespace:            kubeDeployment.Namespace,
+		Version:              kubeDeployment.APIVersion,
+		Cluster:              kubeDeployment.ClusterName,
+		Labels:               kubeDeployment.Labels,
+		Annotations:          kubeDeployment.Annotations,
+		Replicas:             kubeDeployment.Spec.Replicas,
+		Selector:             selector.NewLabelSelectorFromKubeLabelSelectorV1(kubeDeployment.Spec.Selector),
+		Strategy:             strategy,
+		MinReady:             kubeDeployment.Spec.MinReadySeconds,
+		RevisionHistoryLimit: kubeDeployment.Spec.RevisionHistoryLimit,
+		Paused:               kubeDeployment.Spec.Paused,
+		ProgressDeadline:     kubeDeployment.Spec.ProgressDeadlineSeconds,
+		Template:             *template,
+	}, nil
+}
+
+func fromKubeDeploymentV1beta1(kubeDeployment *appsv1beta1.Deployment) (*Deployment, error) {
+	d, err := fromKubeDeploymentViaV1(kubeDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	if kubeDeployment.Spec.RollbackTo != nil {
+		d.RollbackTo = &kubeDeployment.Spec.RollbackTo.Revision
+	}
+
+	return d, nil
+}
+
+func fromKubeDeploymentV1beta2(kubeDeployment *appsv1beta2.Deployment) (*Deployment, error) {
+	return fromKubeDeploymentViaV1(kubeDeployment)
+}
+
+func fromKubeDeploymentExtensionsV1beta1(kubeDeployment *extensionsv1beta1.Deployment) (*Deployment, error) {
+	d, err := fromKubeDeploymentViaV1(kubeDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	if kubeDeployment.Spec.RollbackTo != nil {
+		d.RollbackTo = &kubeDeployment.Spec.RollbackTo.Revision
+	}
+
+	return d, nil
+}
+
+// fromKubeDeploymentViaV1 converts a deployment of an older api version,
+// which has the same fields as apps/v1 except for rollbackTo.  The api
+// version of the original is kept.
+func fromKubeDeploymentViaV1(kubeDeployment interface{}) (*Deployment, error) {
+	v1Deployment := &appsv1.Deployment{}
+	err := converterutils.ConvertKubeObject(kubeDeployment, v1Deployment)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeDeployment, "couldn't convert to %s: %s", appsv1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeDeploymentV1(v1Deployment)
+}
+
+func fromKubeDeploymentStrategyV1(kubeStrategy *appsv1.DeploymentStrategy) (*Strategy, error) {
+	if len(kubeStrategy.Type) == 0 && kubeStrategy.RollingUpdate == nil {
+		return nil, nil
+	}
+
+	strategy := &Strategy{}
+	switch kubeStrategy.Type {
+	case appsv1.RecreateDeploymentStrategyType:
+		strategy.Type = StrategyTypeRecreate
+		if kubeStrategy.RollingUpdate != nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeStrategy, "rollingUpdate can't be set for the %s strategy", appsv1.RecreateDeploymentStrategyType)
+		}
+	case appsv1.RollingUpdateDeploymentStrategyType, "":
+		strategy.Type = StrategyTypeRolling
+		if kubeStrategy.RollingUpdate != nil {
+			strategy.Rolling = &RollingUpdate{
+				MaxUnavailable: kubeStrategy.RollingUpdate.MaxUnavailable,
+				MaxSurge:       kubeStrategy.RollingUpdate.MaxSurge,
+			}
+		}
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeStrategy.Type, "unrecognized deployment strategy")
+	}
+
+	return strategy, nil
+}
@@ -0,0 +1,36 @@
+package deployment
+
+import (
+	"mantle/pkg/registry"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "deployment"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			appsv1.SchemeGroupVersion.WithKind("Deployment"),
+			appsv1beta1.SchemeGroupVersion.WithKind("Deployment"),
+			appsv1beta2.SchemeGroupVersion.WithKind("Deployment"),
+			extensionsv1beta1.SchemeGroupVersion.WithKind("Deployment"),
+		},
+		New: func() registry.Object {
+			return &Deployment{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			d, err := NewDeploymentFromKubeDeployment(obj)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		},
+	})
+}
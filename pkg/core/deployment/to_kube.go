@@ -0,0 +1,168 @@
+package deployment
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes deployment object of the api version
+// type defined in the object
+func (d *Deployment) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(d.Version) {
+	case "apps/v1":
+		return d.toKubeV1()
+	case "":
+		return d.toKubeV1()
+	case "apps/v1beta1":
+		return d.toKubeV1beta1()
+	case "apps/v1beta2":
+		return d.toKubeV1beta2()
+	case "extensions/v1beta1":
+		return d.toKubeExtensionsV1beta1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for deployment: %s", d.Version)
+	}
+}
+
+func (d *Deployment) toKubeV1() (*appsv1.Deployment, error) {
+	if d.RollbackTo != nil && !d.supportsRollbackTo() {
+		return nil, serrors.InvalidInstanceErrorf(d, "rollbackTo isn't supported by %s", d.Version)
+	}
+
+	template, err := d.Template.ToKube("v1")
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.template")
+	}
+
+	strategy, err := d.toKubeV1DeploymentStrategy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.strategy")
+	}
+
+	kubeDeployment := &appsv1.Deployment{}
+	kubeDeployment.Name = d.Name
+	kubeDeployment.Namespace = d.Namespace
+	kubeDeployment.APIVersion = "apps/v1"
+	kubeDeployment.ClusterName = d.Cluster
+	kubeDeployment.Kind = "Deployment"
+	kubeDeployment.Labels = d.Labels
+	kubeDeployment.Annotations = d.Annotations
+	kubeDeployment.Spec = appsv1.DeploymentSpec{
+		Replicas:                d.Replicas,
+		Selector:                d.Selector.ToKubeLabelSelectorV1(),
+		Template:                *template.(*v1.PodTemplateSpec),
+		Strategy:                strategy,
+		MinReadySeconds:         d.MinReady,
+		RevisionHistoryLimit:    d.RevisionHistoryLimit,
+		Paused:                  d.Paused,
+		ProgressDeadlineSeconds: d.ProgressDeadline,
+	}
+
+	return kubeDeployment, nil
+}
+
+func (d *Deployment) toKubeV1beta1() (*appsv1beta1.Deployment, error) {
+	kubeDeployment := &appsv1beta1.Deployment{}
+	err := d.toKubeViaV1(kubeDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeDeployment.SetGroupVersionKind(appsv1beta1.SchemeGroupVersion.WithKind("Deployment"))
+	if d.RollbackTo != nil {
+		kubeDeployment.Spec.RollbackTo = &appsv1beta1.RollbackConfig{Revision: *d.RollbackTo}
+	}
+
+	return kubeDeployment, nil
+}
+
+func (d *Deployment) toKubeV1beta2() (*appsv1beta2.Deployment, error) {
+	kubeDeployment := &appsv1beta2.Deployment{}
+	err := d.toKubeViaV1(kubeDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeDeployment.SetGroupVersionKind(appsv1beta2.SchemeGroupVersion.WithKind("Deployment"))
+	return kubeDeployment, nil
+}
+
+func (d *Deployment) toKubeExtensionsV1beta1() (*extensionsv1beta1.Deployment, error) {
+	kubeDeployment := &extensionsv1beta1.Deployment{}
+	err := d.toKubeViaV1(kubeDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeDeployment.SetGroupVersionKind(extensionsv1beta1.SchemeGroupVersion.WithKind("Deployment"))
+	if d.RollbackTo != nil {
+		kubeDeployment.Spec.RollbackTo = &extensionsv1beta1.RollbackConfig{Revision: *d.RollbackTo}
+	}
+
+	return kubeDeployment, nil
+}
+
+// toKubeViaV1 converts the deployment to apps/v1 and from there into
+// kubeDeployment, a deployment of an older api version
+func (d *Deployment) toKubeViaV1(kubeDeployment interface{}) error {
+	v1Deployment, err := d.toKubeV1()
+	if err != nil {
+		return err
+	}
+
+	err = converterutils.ConvertKubeObject(v1Deployment, kubeDeployment)
+	if err != nil {
+		return serrors.InvalidInstanceErrorf(d, "couldn't convert to %s: %s", d.Version, err)
+	}
+
+	return nil
+}
+
+func (d *Deployment) supportsRollbackTo() bool {
+	switch strings.ToLower(d.Version) {
+	case "apps/v1beta1", "extensions/v1beta1":
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *Deployment) toKubeV1DeploymentStrategy() (appsv1.DeploymentStrategy, error) {
+	if d.Strategy == nil {
+		return appsv1.DeploymentStrategy{}, nil
+	}
+
+	switch d.Strategy.Type {
+	case StrategyTypeRecreate:
+		if d.Strategy.Rolling != nil {
+			return appsv1.DeploymentStrategy{}, serrors.InvalidInstanceErrorf(d.Strategy, "the %s strategy doesn't take any parameters", StrategyTypeRecreate)
+		}
+		return appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}, nil
+	case StrategyTypeRolling:
+		strategy := appsv1.DeploymentStrategy{
+			Type: appsv1.RollingUpdateDeploymentStrategyType,
+		}
+		if d.Strategy.Rolling != nil {
+			strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+				MaxUnavailable: d.Strategy.Rolling.MaxUnavailable,
+				MaxSurge:       d.Strategy.Rolling.MaxSurge,
+			}
+		}
+		return strategy, nil
+	default:
+		return appsv1.DeploymentStrategy{}, serrors.InvalidValueErrorf(d.Strategy.Type, "unrecognized deployment strategy")
+	}
+}
@@ -0,0 +1,179 @@
+package horizontalpodautoscaler
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/pkg/core/selector"
+
+	serrors "github.com/koki/structurederrors"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/core/v1"
+)
+
+// NewHorizontalPodAutoscalerFromKubeHorizontalPodAutoscaler will create a
+// new HorizontalPodAutoscaler object with the data from a provided
+// kubernetes horizontal pod autoscaler object of any supported api version
+func NewHorizontalPodAutoscalerFromKubeHorizontalPodAutoscaler(obj interface{}) (*HorizontalPodAutoscaler, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(autoscalingv2beta1.HorizontalPodAutoscaler{}):
+		o := obj.(autoscalingv2beta1.HorizontalPodAutoscaler)
+		return fromKubeHorizontalPodAutoscalerV2beta1(&o)
+	case reflect.TypeOf(&autoscalingv2beta1.HorizontalPodAutoscaler{}):
+		return fromKubeHorizontalPodAutoscalerV2beta1(obj.(*autoscalingv2beta1.HorizontalPodAutoscaler))
+	case reflect.TypeOf(autoscalingv1.HorizontalPodAutoscaler{}):
+		o := obj.(autoscalingv1.HorizontalPodAutoscaler)
+		return fromKubeHorizontalPodAutoscalerV1(&o)
+	case reflect.TypeOf(&autoscalingv1.HorizontalPodAutoscaler{}):
+		return fromKubeHorizontalPodAutoscalerV1(obj.(*autoscalingv1.HorizontalPodAutoscaler))
+	default:
+		return nil, fmt.Errorf("unknown HorizontalPodAutoscaler version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeHorizontalPodAutoscalerV2beta1(kubeAutoscaler *autoscalingv2beta1.HorizontalPodAutoscaler) (*HorizontalPodAutoscaler, error) {
+	target, err := fromKubeObjectRefV2beta1(kubeAutoscaler.Spec.ScaleTargetRef)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.scaleTargetRef")
+	}
+
+	metrics, err := fromKubeMetricsV2beta1(kubeAutoscaler.Spec.Metrics)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.metrics")
+	}
+
+	return &HorizontalPodAutoscaler{
+		Name:        kubeAutoscaler.Name,
+		Namespace:   kubeAutoscaler.Namespace,
+		Version:     kubeAutoscaler.APIVersion,
+		Cluster:     kubeAutoscaler.ClusterName,
+		Labels:      kubeAutoscaler.Labels,
+		Annotations: kubeAutoscaler.Annotations,
+		Target:      *target,
+		MinReplicas: kubeAutoscaler.Spec.MinReplicas,
+		MaxReplicas: kubeAutoscaler.Spec.MaxReplicas,
+		Metrics:     metrics,
+	}, nil
+}
+
+// fromKubeHorizontalPodAutoscalerV1 converts an autoscaling/v1
+// autoscaler, whose cpu utilization target becomes a resource metric.  The
+// api version of the original is kept.
+func fromKubeHorizontalPodAutoscalerV1(kubeAutoscaler *autoscalingv1.HorizontalPodAutoscaler) (*HorizontalPodAutoscaler, error) {
+	target, err := fromKubeObjectRefV2beta1(autoscalingv2beta1.CrossVersionObjectReference(kubeAutoscaler.Spec.ScaleTargetRef))
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.scaleTargetRef")
+	}
+
+	var metrics []Metric
+	if kubeAutoscaler.Spec.TargetCPUUtilizationPercentage != nil {
+		metrics = []Metric{
+			{
+				Type:        MetricTypeResource,
+				Name:        string(v1.ResourceCPU),
+				Utilization: kubeAutoscaler.Spec.TargetCPUUtilizationPercentage,
+			},
+		}
+	}
+
+	return &HorizontalPodAutoscaler{
+		Name:        kubeAutoscaler.Name,
+		Namespace:   kubeAutoscaler.Namespace,
+		Version:     kubeAutoscaler.APIVersion,
+		Cluster:     kubeAutoscaler.ClusterName,
+		Labels:      kubeAutoscaler.Labels,
+		Annotations: kubeAutoscaler.Annotations,
+		Target:      *target,
+		MinReplicas: kubeAutoscaler.Spec.MinReplicas,
+		MaxReplicas: kubeAutoscaler.Spec.MaxReplicas,
+		Metrics:     metrics,
+	}, nil
+}
+
+func fromKubeObjectRefV2beta1(kubeRef autoscalingv2beta1.CrossVersionObjectReference) (*ObjectRef, error) {
+	ref := &ObjectRef{
+		APIVersion: kubeRef.APIVersion,
+		Kind:       kubeRef.Kind,
+		Name:       kubeRef.Name,
+	}
+
+	// make sure the reference can be written as a string
+	_, err := ref.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return ref, nil
+}
+
+func fromKubeMetricsV2beta1(kubeMetrics []autoscalingv2beta1.MetricSpec) ([]Metric, error) {
+	if len(kubeMetrics) == 0 {
+		return nil, nil
+	}
+
+	metrics := make([]Metric, len(kubeMetrics))
+	for i, kubeMetric := range kubeMetrics {
+		metric, err := fromKubeMetricV2beta1(kubeMetric)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		metrics[i] = *metric
+	}
+
+	return metrics, nil
+}
+
+func fromKubeMetricV2beta1(kubeMetric autoscalingv2beta1.MetricSpec) (*Metric, error) {
+	switch kubeMetric.Type {
+	case autoscalingv2beta1.ResourceMetricSourceType:
+		if kubeMetric.Resource == nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeMetric, "missing resource metric source")
+		}
+		return &Metric{
+			Type:         MetricTypeResource,
+			Name:         string(kubeMetric.Resource.Name),
+			Utilization:  kubeMetric.Resource.TargetAverageUtilization,
+			AverageValue: kubeMetric.Resource.TargetAverageValue,
+		}, nil
+	case autoscalingv2beta1.PodsMetricSourceType:
+		if kubeMetric.Pods == nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeMetric, "missing pods metric source")
+		}
+		value := kubeMetric.Pods.TargetAverageValue
+		return &Metric{
+			Type:         MetricTypePods,
+			Name:         kubeMetric.Pods.MetricName,
+			AverageValue: &value,
+		}, nil
+	case autoscalingv2beta1.ObjectMetricSourceType:
+		if kubeMetric.Object == nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeMetric, "missing object metric source")
+		}
+		object, err := fromKubeObjectRefV2beta1(kubeMetric.Object.Target)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.object.target")
+		}
+		value := kubeMetric.Object.TargetValue
+		return &Metric{
+			Type:   MetricTypeObject,
+			Name:   kubeMetric.Object.MetricName,
+			Value:  &value,
+			Object: object,
+		}, nil
+	case autoscalingv2beta1.ExternalMetricSourceType:
+		if kubeMetric.External == nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeMetric, "missing external metric source")
+		}
+		return &Metric{
+			Type:         MetricTypeExternal,
+			Name:         kubeMetric.External.MetricName,
+			Value:        kubeMetric.External.TargetValue,
+			AverageValue: kubeMetric.External.TargetAverageValue,
+			Selector:     selector.NewLabelSelectorFromKubeLabelSelectorV1(kubeMetric.External.MetricSelector),
+		}, nil
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeMetric.Type, "unrecognized metric type")
+	}
+}
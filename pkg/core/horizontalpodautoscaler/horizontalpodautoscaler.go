@@ -0,0 +1,125 @@
+package horizontalpodautoscaler
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// HorizontalPodAutoscaler defines a horizontal pod autoscaler object.  The
+// status of the autoscaler is dropped.
+//
+// Autoscalers without a version are converted to autoscaling/v1 if their
+// only metric is the cpu utilization, and to autoscaling/v2beta1
+// otherwise.
+type HorizontalPodAutoscaler struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Target      ObjectRef `json:"target"`
+	MinReplicas *int32    `json:"minReplicas,omitempty"`
+	MaxReplicas int32     `json:"maxReplicas"`
+	Metrics     []Metric  `json:"metrics,omitempty"`
+}
+
+const refSeparator = ":"
+
+// ObjectRef refers to an object by its kind and name.  It is written as
+// "[apiVersion:]kind:name" with the kind in kebab case, e.g.
+// "deployment:web" or "apps/v1:stateful-set:db".
+type ObjectRef struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+func (r *ObjectRef) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected an object reference string")
+	}
+
+	return r.Unmarshal(str)
+}
+
+// Unmarshal reads the "[apiVersion:]kind:name" shorthand
+func (r *ObjectRef) Unmarshal(str string) error {
+	segments := strings.Split(str, refSeparator)
+	if len(segments) == 2 {
+		segments = append([]string{""}, segments...)
+	}
+	if len(segments) != 3 || len(segments[1]) == 0 || len(segments[2]) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected [apiVersion:]kind:name")
+	}
+
+	*r = ObjectRef{
+		APIVersion: segments[0],
+		Kind:       kebabToKind(segments[1]),
+		Name:       segments[2],
+	}
+	return nil
+}
+
+func (r ObjectRef) MarshalJSON() ([]byte, error) {
+	str, err := r.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(str)
+}
+
+// Marshal returns the "[apiVersion:]kind:name" shorthand for the reference
+func (r ObjectRef) Marshal() (string, error) {
+	kind := kindToKebab(r.Kind)
+	if kebabToKind(kind) != r.Kind {
+		return "", serrors.InvalidValueErrorf(r.Kind, "kind can't be written in kebab case")
+	}
+
+	segments := []string{kind, r.Name}
+	if len(r.APIVersion) > 0 {
+		segments = append([]string{r.APIVersion}, segments...)
+	}
+	for _, segment := range segments {
+		if strings.Contains(segment, refSeparator) {
+			return "", serrors.InvalidInstanceErrorf(r, "%s can't contain %s", segment, refSeparator)
+		}
+	}
+
+	return strings.Join(segments, refSeparator), nil
+}
+
+// kindToKebab writes a kind such as "ReplicaSet" as "replica-set"
+func kindToKebab(kind string) string {
+	kebab := []rune{}
+	for i, r := range kind {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				kebab = append(kebab, '-')
+			}
+			r = unicode.ToLower(r)
+		}
+		kebab = append(kebab, r)
+	}
+
+	return string(kebab)
+}
+
+// kebabToKind reads a kind written by kindToKebab
+func kebabToKind(kebab string) string {
+	words := strings.Split(kebab, "-")
+	for i, word := range words {
+		if len(word) > 0 {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return strings.Join(words, "")
+}
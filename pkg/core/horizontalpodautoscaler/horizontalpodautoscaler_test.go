@@ -0,0 +1,187 @@
+package horizontalpodautoscaler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMetricShorthand(t *testing.T) {
+	testcases := []struct {
+		str  string
+		pass bool
+	}{
+		{str: `{"cpu":"70%"}`, pass: true},
+		{str: `{"memory":"500Mi"}`, pass: true},
+		{str: `{"pods/requests_per_second":"100"}`, pass: true},
+		{str: `{"object/requests_per_second":"10k on extensions/v1beta1:ingress:main"}`, pass: true},
+		{str: `{"external/queue_length":"30 where queue=jobs"}`, pass: true},
+		{str: `{"external/queue_length":"5 per pod"}`, pass: true},
+		{str: `{"cpu":"seventy%"}`, pass: false},
+		{str: `{"pods/":"100"}`, pass: false},
+		{str: `{"object/requests_per_second":"10k"}`, pass: false},
+		{str: `{"cpu":"70%","memory":"500Mi"}`, pass: false},
+	}
+
+	for _, tc := range testcases {
+		metric := Metric{}
+		err := json.Unmarshal([]byte(tc.str), &metric)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+			continue
+		}
+		if !tc.pass {
+			continue
+		}
+
+		data, err := json.Marshal(metric)
+		if err != nil {
+			t.Errorf("%s: marshal failed: %v", tc.str, err)
+		} else if string(data) != tc.str {
+			t.Errorf("%s: round trip produced %s", tc.str, data)
+		}
+	}
+}
+
+func TestObjectRefShorthand(t *testing.T) {
+	ref := ObjectRef{}
+	if err := json.Unmarshal([]byte(`"apps/v1:replica-set:web"`), &ref); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	expected := ObjectRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web"}
+	if ref != expected {
+		t.Errorf("expected %#v, got %#v", expected, ref)
+	}
+
+	if _, err := (ObjectRef{Kind: "replicaSet", Name: "web"}).Marshal(); err == nil {
+		t.Errorf("expected an error for a kind that isn't camel case")
+	}
+}
+
+func TestHorizontalPodAutoscalerRoundTrip(t *testing.T) {
+	minReplicas := int32(2)
+	utilization := int32(70)
+	memory := resource.MustParse("500Mi")
+	kubeAutoscaler := &autoscalingv2beta1.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v2beta1",
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "testNS",
+		},
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
+				Kind: "Deployment",
+				Name: "web",
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: 10,
+			Metrics: []autoscalingv2beta1.MetricSpec{
+				{
+					Type: autoscalingv2beta1.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta1.ResourceMetricSource{
+						Name:                     v1.ResourceCPU,
+						TargetAverageUtilization: &utilization,
+					},
+				},
+				{
+					Type: autoscalingv2beta1.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta1.ResourceMetricSource{
+						Name:               v1.ResourceMemory,
+						TargetAverageValue: &memory,
+					},
+				},
+				{
+					Type: autoscalingv2beta1.PodsMetricSourceType,
+					Pods: &autoscalingv2beta1.PodsMetricSource{
+						MetricName:         "requests_per_second",
+						TargetAverageValue: resource.MustParse("100"),
+					},
+				},
+			},
+		},
+	}
+
+	a, err := NewHorizontalPodAutoscalerFromKubeHorizontalPodAutoscaler(kubeAutoscaler)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	expected := `{"version":"autoscaling/v2beta1","name":"web","namespace":"testNS","target":"deployment:web",` +
+		`"minReplicas":2,"maxReplicas":10,"metrics":[{"cpu":"70%"},{"memory":"500Mi"},{"pods/requests_per_second":"100"}]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	a = &HorizontalPodAutoscaler{}
+	if err := json.Unmarshal(data, a); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := a.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeAutoscaler) {
+		t.Errorf("round trip changed the autoscaler\nexpected %#v\ngot      %#v", kubeAutoscaler, obj)
+	}
+
+	a.Version = "autoscaling/v1"
+	if _, err := a.ToKube(); err == nil {
+		t.Errorf("expected an error converting memory and pods metrics to autoscaling/v1")
+	}
+}
+
+func TestHorizontalPodAutoscalerCPUOnly(t *testing.T) {
+	data := []byte(`{"name":"web","target":"deployment:web","maxReplicas":5,"metrics":[{"cpu":"80%"}]}`)
+	a := &HorizontalPodAutoscaler{}
+	if err := json.Unmarshal(data, a); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	// without a version a cpu only autoscaler is written for autoscaling/v1
+	obj, err := a.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	kubeAutoscaler, ok := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	if !ok {
+		t.Fatalf("expected an autoscaling/v1 autoscaler, got %T", obj)
+	}
+	spec := kubeAutoscaler.Spec
+	if spec.ScaleTargetRef.Kind != "Deployment" || spec.TargetCPUUtilizationPercentage == nil || *spec.TargetCPUUtilizationPercentage != 80 {
+		t.Errorf("unexpected spec %#v", spec)
+	}
+
+	v1Autoscaler, err := NewHorizontalPodAutoscalerFromKubeHorizontalPodAutoscaler(kubeAutoscaler)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+	v1Autoscaler.Version = ""
+	if !reflect.DeepEqual(v1Autoscaler, a) {
+		t.Errorf("expected %#v, got %#v", a, v1Autoscaler)
+	}
+
+	// the same autoscaler can also be written for autoscaling/v2beta1
+	a.Version = "autoscaling/v2beta1"
+	obj, err = a.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to autoscaling/v2beta1 failed: %v", err)
+	}
+	if _, ok := obj.(*autoscalingv2beta1.HorizontalPodAutoscaler); !ok {
+		t.Errorf("expected an autoscaling/v2beta1 autoscaler, got %T", obj)
+	}
+}
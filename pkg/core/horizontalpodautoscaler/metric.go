@@ -0,0 +1,207 @@
+package horizontalpodautoscaler
+
+import (
+	"strconv"
+	"strings"
+
+	"mantle/internal/pkg/core/selector"
+
+	"github.com/koki/json"
+	"github.com/koki/json/jsonutil"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type MetricType string
+
+const (
+	MetricTypeResource MetricType = "resource"
+	MetricTypePods     MetricType = "pods"
+	MetricTypeObject   MetricType = "object"
+	MetricTypeExternal MetricType = "external"
+)
+
+const (
+	metricSeparator   = "/"
+	percentSuffix     = "%"
+	objectSeparator   = " on "
+	perPodSuffix      = " per pod"
+	selectorSeparator = " where "
+)
+
+// Metric is a target value that the autoscaler scales on.  It is written
+// as a single entry dictionary from the metric to the target:
+//
+//	cpu: 70%                          average utilization of a resource
+//	memory: 500Mi                     average value of a resource
+//	pods/requests_per_second: 100     average value of a pod metric
+//	object/requests_per_second: 10k on ingress:main
+//	external/queue_length: 30 where queue=jobs
+//	external/queue_length: 5 per pod
+//
+// Object metrics name the object that the metric describes, and
+// external metrics can select the metric by its labels.
+type Metric struct {
+	Type MetricType
+	// Name is the resource name for resource metrics and the metric name
+	// otherwise
+	Name string
+
+	Utilization  *int32
+	Value        *resource.Quantity
+	AverageValue *resource.Quantity
+
+	Object   *ObjectRef
+	Selector *selector.LabelSelector
+}
+
+func (m *Metric) UnmarshalJSON(data []byte) error {
+	obj := map[string]interface{}{}
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a dictionary with a single metric")
+	}
+
+	key, val, err := jsonutil.GetOnlyMapEntry(obj)
+	if err != nil {
+		return err
+	}
+
+	var target string
+	switch val := val.(type) {
+	case string:
+		target = val
+	case float64:
+		target = strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return serrors.InvalidValueErrorf(val, "expected a target value for %s", key)
+	}
+
+	return m.Unmarshal(key, target)
+}
+
+// Unmarshal reads the metric and target of the shorthand
+func (m *Metric) Unmarshal(key, target string) error {
+	*m = Metric{Type: MetricTypeResource, Name: key}
+	if segments := strings.SplitN(key, metricSeparator, 2); len(segments) == 2 {
+		switch metricType := MetricType(segments[0]); metricType {
+		case MetricTypePods, MetricTypeObject, MetricTypeExternal:
+			m.Type, m.Name = metricType, segments[1]
+		}
+	}
+	if len(m.Name) == 0 {
+		return serrors.InvalidValueErrorf(key, "expected a metric name")
+	}
+
+	var err error
+	switch m.Type {
+	case MetricTypeResource:
+		if strings.HasSuffix(target, percentSuffix) {
+			utilization, err := strconv.ParseInt(strings.TrimSuffix(target, percentSuffix), 10, 32)
+			if err != nil {
+				return serrors.InvalidValueErrorf(target, "invalid utilization: %s", err)
+			}
+			u := int32(utilization)
+			m.Utilization = &u
+			return nil
+		}
+		m.AverageValue, err = parseQuantity(target)
+	case MetricTypePods:
+		m.AverageValue, err = parseQuantity(target)
+	case MetricTypeObject:
+		segments := strings.SplitN(target, objectSeparator, 2)
+		if len(segments) != 2 {
+			return serrors.InvalidValueErrorf(target, "expected value%sobject", objectSeparator)
+		}
+		m.Object = &ObjectRef{}
+		err = m.Object.Unmarshal(strings.TrimSpace(segments[1]))
+		if err == nil {
+			m.Value, err = parseQuantity(segments[0])
+		}
+	case MetricTypeExternal:
+		if segments := strings.SplitN(target, selectorSeparator, 2); len(segments) == 2 {
+			m.Selector = &selector.LabelSelector{}
+			err = m.Selector.Unmarshal(strings.TrimSpace(segments[1]))
+			if err != nil {
+				return err
+			}
+			target = segments[0]
+		}
+		if strings.HasSuffix(target, perPodSuffix) {
+			m.AverageValue, err = parseQuantity(strings.TrimSuffix(target, perPodSuffix))
+		} else {
+			m.Value, err = parseQuantity(target)
+		}
+	}
+
+	return err
+}
+
+func (m Metric) MarshalJSON() ([]byte, error) {
+	key, target, err := m.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]string{key: target})
+}
+
+// Marshal returns the metric and target of the shorthand
+func (m Metric) Marshal() (string, string, error) {
+	key := string(m.Type) + metricSeparator + m.Name
+	switch m.Type {
+	case MetricTypeResource:
+		key = m.Name
+		if m.Utilization != nil && m.AverageValue == nil {
+			return key, strconv.Itoa(int(*m.Utilization)) + percentSuffix, nil
+		}
+		if m.Utilization == nil && m.AverageValue != nil {
+			return key, m.AverageValue.String(), nil
+		}
+		return "", "", serrors.InvalidInstanceErrorf(m, "a resource metric needs either a utilization or an average value")
+	case MetricTypePods:
+		if m.AverageValue == nil {
+			return "", "", serrors.InvalidInstanceErrorf(m, "a pods metric needs an average value")
+		}
+		return key, m.AverageValue.String(), nil
+	case MetricTypeObject:
+		if m.Value == nil || m.Object == nil {
+			return "", "", serrors.InvalidInstanceErrorf(m, "an object metric needs a value and an object")
+		}
+		object, err := m.Object.Marshal()
+		if err != nil {
+			return "", "", err
+		}
+		return key, m.Value.String() + objectSeparator + object, nil
+	case MetricTypeExternal:
+		var target string
+		switch {
+		case m.Value != nil && m.AverageValue == nil:
+			target = m.Value.String()
+		case m.Value == nil && m.AverageValue != nil:
+			target = m.AverageValue.String() + perPodSuffix
+		default:
+			return "", "", serrors.InvalidInstanceErrorf(m, "an external metric needs either a value or an average value")
+		}
+		if m.Selector != nil {
+			str, err := m.Selector.Marshal()
+			if err != nil {
+				return "", "", err
+			}
+			target += selectorSeparator + str
+		}
+		return key, target, nil
+	default:
+		return "", "", serrors.InvalidValueErrorf(m.Type, "unrecognized metric type")
+	}
+}
+
+func parseQuantity(str string) (*resource.Quantity, error) {
+	quantity, err := resource.ParseQuantity(strings.TrimSpace(str))
+	if err != nil {
+		return nil, serrors.InvalidValueErrorf(str, "invalid quantity: %s", err)
+	}
+
+	return &quantity, nil
+}
@@ -0,0 +1,32 @@
+package horizontalpodautoscaler
+
+import (
+	"mantle/pkg/registry"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "horizontal_pod_autoscaler"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			autoscalingv1.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"),
+			autoscalingv2beta1.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"),
+		},
+		New: func() registry.Object {
+			return &HorizontalPodAutoscaler{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			a, err := NewHorizontalPodAutoscalerFromKubeHorizontalPodAutoscaler(obj)
+			if err != nil {
+				return nil, err
+			}
+			return a, nil
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package horizontalpodautoscaler
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes horizontal pod autoscaler object of the
+// api version type defined in the object
+func (a *HorizontalPodAutoscaler) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(a.Version) {
+	case "autoscaling/v1":
+		return a.toKubeV1()
+	case "autoscaling/v2beta1":
+		return a.toKubeV2beta1()
+	case "":
+		if a.cpuUtilizationOnly() {
+			return a.toKubeV1()
+		}
+		return a.toKubeV2beta1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for horizontal pod autoscaler: %s", a.Version)
+	}
+}
+
+// cpuUtilizationOnly is true if the metrics can be written for
+// autoscaling/v1, which only knows the cpu utilization
+func (a *HorizontalPodAutoscaler) cpuUtilizationOnly() bool {
+	switch len(a.Metrics) {
+	case 0:
+		return true
+	case 1:
+		m := a.Metrics[0]
+		return m.Type == MetricTypeResource && m.Name == string(v1.ResourceCPU) &&
+			m.Utilization != nil && m.AverageValue == nil
+	default:
+		return false
+	}
+}
+
+func (a *HorizontalPodAutoscaler) toKubeV1() (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	if !a.cpuUtilizationOnly() {
+		err := serrors.InvalidInstanceErrorf(a.Metrics, "autoscaling/v1 only supports a cpu utilization metric")
+		return nil, serrors.ContextualizeErrorf(err, "$.metrics")
+	}
+
+	kubeAutoscaler := &autoscalingv1.HorizontalPodAutoscaler{}
+	kubeAutoscaler.Name = a.Name
+	kubeAutoscaler.Namespace = a.Namespace
+	kubeAutoscaler.APIVersion = "autoscaling/v1"
+	kubeAutoscaler.ClusterName = a.Cluster
+	kubeAutoscaler.Kind = "HorizontalPodAutoscaler"
+	kubeAutoscaler.Labels = a.Labels
+	kubeAutoscaler.Annotations = a.Annotations
+	kubeAutoscaler.Spec = autoscalingv1.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv1.CrossVersionObjectReference(a.Target.toKubeV2beta1()),
+		MinReplicas:    a.MinReplicas,
+		MaxReplicas:    a.MaxReplicas,
+	}
+	if len(a.Metrics) > 0 {
+		kubeAutoscaler.Spec.TargetCPUUtilizationPercentage = a.Metrics[0].Utilization
+	}
+
+	return kubeAutoscaler, nil
+}
+
+func (a *HorizontalPodAutoscaler) toKubeV2beta1() (*autoscalingv2beta1.HorizontalPodAutoscaler, error) {
+	metrics, err := toKubeMetricsV2beta1(a.Metrics)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.metrics")
+	}
+
+	kubeAutoscaler := &autoscalingv2beta1.HorizontalPodAutoscaler{}
+	kubeAutoscaler.Name = a.Name
+	kubeAutoscaler.Namespace = a.Namespace
+	kubeAutoscaler.APIVersion = "autoscaling/v2beta1"
+	kubeAutoscaler.ClusterName = a.Cluster
+	kubeAutoscaler.Kind = "HorizontalPodAutoscaler"
+	kubeAutoscaler.Labels = a.Labels
+	kubeAutoscaler.Annotations = a.Annotations
+	kubeAutoscaler.Spec = autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: a.Target.toKubeV2beta1(),
+		MinReplicas:    a.MinReplicas,
+		MaxReplicas:    a.MaxReplicas,
+		Metrics:        metrics,
+	}
+
+	return kubeAutoscaler, nil
+}
+
+func (r ObjectRef) toKubeV2beta1() autoscalingv2beta1.CrossVersionObjectReference {
+	return autoscalingv2beta1.CrossVersionObjectReference{
+		APIVersion: r.APIVersion,
+		Kind:       r.Kind,
+		Name:       r.Name,
+	}
+}
+
+func toKubeMetricsV2beta1(metrics []Metric) ([]autoscalingv2beta1.MetricSpec, error) {
+	if len(metrics) == 0 {
+		return nil, nil
+	}
+
+	kubeMetrics := make([]autoscalingv2beta1.MetricSpec, len(metrics))
+	for i, metric := range metrics {
+		kubeMetric, err := metric.toKubeV2beta1()
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		kubeMetrics[i] = *kubeMetric
+	}
+
+	return kubeMetrics, nil
+}
+
+func (m Metric) toKubeV2beta1() (*autoscalingv2beta1.MetricSpec, error) {
+	// catch metrics that are missing their target values
+	_, _, err := m.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	switch m.Type {
+	case MetricTypeResource:
+		return &autoscalingv2beta1.MetricSpec{
+			Type: autoscalingv2beta1.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta1.ResourceMetricSource{
+				Name:                     v1.ResourceName(m.Name),
+				TargetAverageUtilization: m.Utilization,
+				TargetAverageValue:       m.AverageValue,
+			},
+		}, nil
+	case MetricTypePods:
+		return &autoscalingv2beta1.MetricSpec{
+			Type: autoscalingv2beta1.PodsMetricSourceType,
+			Pods: &autoscalingv2beta1.PodsMetricSource{
+				MetricName:         m.Name,
+				TargetAverageValue: *m.AverageValue,
+			},
+		}, nil
+	case MetricTypeObject:
+		return &autoscalingv2beta1.MetricSpec{
+			Type: autoscalingv2beta1.ObjectMetricSourceType,
+			Object: &autoscalingv2beta1.ObjectMetricSource{
+				Target:      m.Object.toKubeV2beta1(),
+				MetricName:  m.Name,
+				TargetValue: *m.Value,
+			},
+		}, nil
+	case MetricTypeExternal:
+		return &autoscalingv2beta1.MetricSpec{
+			Type: autoscalingv2beta1.ExternalMetricSourceType,
+			External: &autoscalingv2beta1.ExternalMetricSource{
+				MetricName:         m.Name,
+				MetricSelector:     m.Selector.ToKubeLabelSelectorV1(),
+				TargetValue:        m.Value,
+				TargetAverageValue: m.AverageValue,
+			},
+		}, nil
+	default:
+		return nil, serrors.InvalidValueErrorf(m.Type, "unrecognized metric type")
+	}
+}
@@ -0,0 +1,85 @@
+package ingress
+
+import (
+	"fmt"
+	"reflect"
+
+	serrors "github.com/koki/structurederrors"
+
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+)
+
+// NewIngressFromKubeIngress will create a new Ingress object with the
+// data from a provided kubernetes ingress object
+func NewIngressFromKubeIngress(obj interface{}) (*Ingress, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(extensionsv1beta1.Ingress{}):
+		o := obj.(extensionsv1beta1.Ingress)
+		return fromKubeIngressV1beta1(&o)
+	case reflect.TypeOf(&extensionsv1beta1.Ingress{}):
+		return fromKubeIngressV1beta1(obj.(*extensionsv1beta1.Ingress))
+	default:
+		return nil, fmt.Errorf("unknown Ingress version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeIngressV1beta1(kubeIngress *extensionsv1beta1.Ingress) (*Ingress, error) {
+	rules, err := fromKubeIngressRulesV1beta1(kubeIngress.Spec.Rules)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.rules")
+	}
+
+	ingress := &Ingress{
+		Name:        kubeIngress.Name,
+		Namespace:   kubeIngress.Namespace,
+		Version:     kubeIngress.APIVersion,
+		Cluster:     kubeIngress.ClusterName,
+		Labels:      kubeIngress.Labels,
+		Annotations: kubeIngress.Annotations,
+		Backend:     fromKubeIngressBackendV1beta1(kubeIngress.Spec.Backend),
+		Rules:       rules,
+	}
+
+	for _, kubeTLS := range kubeIngress.Spec.TLS {
+		ingress.TLS = append(ingress.TLS, TLS{
+			Secret: kubeTLS.SecretName,
+			Hosts:  kubeTLS.Hosts,
+		})
+	}
+
+	return ingress, nil
+}
+
+func fromKubeIngressRulesV1beta1(kubeRules []extensionsv1beta1.IngressRule) ([]Rule, error) {
+	var rules []Rule
+	for i, kubeRule := range kubeRules {
+		if kubeRule.HTTP == nil {
+			rules = append(rules, Rule{Host: kubeRule.Host})
+			continue
+		}
+		if len(kubeRule.HTTP.Paths) == 0 {
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(kubeRule.HTTP, "http needs at least one path"), "$.%d.http", i)
+		}
+
+		for _, kubePath := range kubeRule.HTTP.Paths {
+			rules = append(rules, Rule{
+				Host:    kubeRule.Host,
+				Path:    kubePath.Path,
+				Backend: fromKubeIngressBackendV1beta1(&kubePath.Backend),
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+func fromKubeIngressBackendV1beta1(kubeBackend *extensionsv1beta1.IngressBackend) *Backend {
+	if kubeBackend == nil {
+		return nil
+	}
+
+	return &Backend{
+		ServiceName: kubeBackend.ServiceName,
+		ServicePort: kubeBackend.ServicePort,
+	}
+}
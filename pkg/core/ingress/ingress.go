@@ -0,0 +1,127 @@
+package ingress
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	"github.com/koki/json/jsonutil"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Ingress defines an ingress object.  The status of the ingress is
+// dropped.
+type Ingress struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Backend *Backend `json:"backend,omitempty"`
+	TLS     []TLS    `json:"tls,omitempty"`
+	Rules   []Rule   `json:"rules,omitempty"`
+}
+
+// Backend is a service port traffic is sent to, written as
+// "serviceName:servicePort", e.g. "api-svc:80" or "api-svc:http"
+type Backend struct {
+	ServiceName string
+	ServicePort intstr.IntOrString
+}
+
+// TLS is a secret holding the certificate for a list of hosts
+type TLS struct {
+	Secret string   `json:"secret,omitempty"`
+	Hosts  []string `json:"hosts,omitempty"`
+}
+
+// Rule routes the requests for a host and path to a backend.  It is
+// written as a dictionary from "host/path" to the backend, e.g.
+// {"example.com/api": "api-svc:80"}.  Either the host or the path can be
+// left out.  A host without any paths has a null backend.  Rules for the
+// same host are grouped together in kubernetes, in the order they're
+// listed.
+type Rule struct {
+	Host    string
+	Path    string
+	Backend *Backend
+}
+
+func (b *Backend) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form serviceName:servicePort")
+	}
+
+	return b.Unmarshal(str)
+}
+
+func (b *Backend) Unmarshal(str string) error {
+	segments := strings.Split(str, ":")
+	if len(segments) != 2 || len(segments[0]) == 0 || len(segments[1]) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected serviceName:servicePort")
+	}
+
+	b.ServiceName = segments[0]
+	b.ServicePort = intstr.Parse(segments[1])
+	return nil
+}
+
+func (b Backend) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+func (b Backend) String() string {
+	return b.ServiceName + ":" + b.ServicePort.String()
+}
+
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	obj := map[string]interface{}{}
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a dictionary from host/path to serviceName:servicePort")
+	}
+
+	key, val, err := jsonutil.GetOnlyMapEntry(obj)
+	if err != nil {
+		return err
+	}
+
+	if i := strings.Index(key, "/"); i >= 0 {
+		r.Host, r.Path = key[:i], key[i:]
+	} else {
+		r.Host = key
+	}
+
+	if val == nil {
+		if len(r.Path) > 0 {
+			return serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(val, "a path needs a backend"), "$.%s", key)
+		}
+		return nil
+	}
+
+	str, ok := val.(string)
+	if !ok {
+		return serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(val, "expected serviceName:servicePort"), "$.%s", key)
+	}
+
+	r.Backend = &Backend{}
+	err = r.Backend.Unmarshal(str)
+	if err != nil {
+		return serrors.ContextualizeErrorf(err, "$.%s", key)
+	}
+
+	return nil
+}
+
+func (r Rule) MarshalJSON() ([]byte, error) {
+	if r.Backend == nil {
+		return json.Marshal(map[string]interface{}{r.Host + r.Path: nil})
+	}
+
+	return json.Marshal(map[string]string{r.Host + r.Path: r.Backend.String()})
+}
@@ -0,0 +1,116 @@
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIngressRoundTrip(t *testing.T) {
+	kubeIngress := &extensionsv1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "extensions/v1beta1",
+			Kind:       "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "testNS",
+			Annotations: map[string]string{"kubernetes.io/ingress.class": "nginx"},
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			Backend: &extensionsv1beta1.IngressBackend{ServiceName: "default", ServicePort: intstr.FromInt(80)},
+			TLS: []extensionsv1beta1.IngressTLS{
+				{Hosts: []string{"example.com"}, SecretName: "example-tls"},
+			},
+			Rules: []extensionsv1beta1.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+						HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+							Paths: []extensionsv1beta1.HTTPIngressPath{
+								{Path: "/api", Backend: extensionsv1beta1.IngressBackend{ServiceName: "api-svc", ServicePort: intstr.FromInt(80)}},
+								{Path: "/", Backend: extensionsv1beta1.IngressBackend{ServiceName: "web", ServicePort: intstr.FromString("http")}},
+							},
+						},
+					},
+				},
+				{
+					IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+						HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+							Paths: []extensionsv1beta1.HTTPIngressPath{
+								{Backend: extensionsv1beta1.IngressBackend{ServiceName: "catch-all", ServicePort: intstr.FromInt(8080)}},
+							},
+						},
+					},
+				},
+				{Host: "empty.example.com"},
+			},
+		},
+	}
+
+	i, err := NewIngressFromKubeIngress(kubeIngress)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(i)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	i = &Ingress{}
+	if err := json.Unmarshal(data, i); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := i.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeIngress) {
+		t.Errorf("round trip changed the ingress\nexpected %#v\ngot      %#v", kubeIngress, obj)
+	}
+}
+
+func TestRuleShorthand(t *testing.T) {
+	testcases := []struct {
+		str      string
+		expected Rule
+		pass     bool
+	}{
+		{
+			str:      `{"example.com/api": "api-svc:80"}`,
+			expected: Rule{Host: "example.com", Path: "/api", Backend: &Backend{ServiceName: "api-svc", ServicePort: intstr.FromInt(80)}},
+			pass:     true,
+		},
+		{
+			str:      `{"/static": "cdn:http"}`,
+			expected: Rule{Path: "/static", Backend: &Backend{ServiceName: "cdn", ServicePort: intstr.FromString("http")}},
+			pass:     true,
+		},
+		{
+			str:      `{"example.com": null}`,
+			expected: Rule{Host: "example.com"},
+			pass:     true,
+		},
+		{str: `{"example.com/api": null}`, pass: false},
+		{str: `{"example.com/api": "api-svc"}`, pass: false},
+		{str: `"example.com/api"`, pass: false},
+	}
+
+	for _, tc := range testcases {
+		rule := Rule{}
+		err := json.Unmarshal([]byte(tc.str), &rule)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+			continue
+		}
+		if tc.pass && !reflect.DeepEqual(rule, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.str, tc.expected, rule)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package ingress
+
+import (
+	"mantle/pkg/registry"
+
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "ingress"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			extensionsv1beta1.SchemeGroupVersion.WithKind("Ingress"),
+		},
+		New: func() registry.Object {
+			return &Ingress{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			i, err := NewIngressFromKubeIngress(obj)
+			if err != nil {
+				return nil, err
+			}
+			return i, nil
+		},
+	})
+}
@@ -0,0 +1,98 @@
+package ingress
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes ingress object of the api version type
+// defined in the object
+func (i *Ingress) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(i.Version) {
+	case "extensions/v1beta1":
+		return i.toKubeV1beta1()
+	case "":
+		return i.toKubeV1beta1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for ingress: %s", i.Version)
+	}
+}
+
+func (i *Ingress) toKubeV1beta1() (*extensionsv1beta1.Ingress, error) {
+	rules, err := i.toKubeV1beta1IngressRules()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.rules")
+	}
+
+	kubeIngress := &extensionsv1beta1.Ingress{}
+	kubeIngress.Name = i.Name
+	kubeIngress.Namespace = i.Namespace
+	kubeIngress.APIVersion = "extensions/v1beta1"
+	kubeIngress.ClusterName = i.Cluster
+	kubeIngress.Kind = "Ingress"
+	kubeIngress.Labels = i.Labels
+	kubeIngress.Annotations = i.Annotations
+	kubeIngress.Spec.Backend = toKubeV1beta1IngressBackend(i.Backend)
+	kubeIngress.Spec.Rules = rules
+
+	for _, tls := range i.TLS {
+		kubeIngress.Spec.TLS = append(kubeIngress.Spec.TLS, extensionsv1beta1.IngressTLS{
+			Hosts:      tls.Hosts,
+			SecretName: tls.Secret,
+		})
+	}
+
+	return kubeIngress, nil
+}
+
+// toKubeV1beta1IngressRules groups the paths of each host into a single
+// rule, in the order the hosts first appear
+func (i *Ingress) toKubeV1beta1IngressRules() ([]extensionsv1beta1.IngressRule, error) {
+	var kubeRules []extensionsv1beta1.IngressRule
+	ruleIndex := map[string]int{}
+	for n, rule := range i.Rules {
+		index, ok := ruleIndex[rule.Host]
+		if !ok {
+			index = len(kubeRules)
+			ruleIndex[rule.Host] = index
+			kubeRules = append(kubeRules, extensionsv1beta1.IngressRule{Host: rule.Host})
+		}
+
+		kubeRule := &kubeRules[index]
+		if rule.Backend == nil {
+			if ok {
+				return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(rule, "host %s is already listed, it can't also be listed without a backend", rule.Host), "$.%d", n)
+			}
+			continue
+		}
+		if ok && kubeRule.HTTP == nil {
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(rule, "host %s is already listed without a backend", rule.Host), "$.%d", n)
+		}
+
+		if kubeRule.HTTP == nil {
+			kubeRule.HTTP = &extensionsv1beta1.HTTPIngressRuleValue{}
+		}
+		kubeRule.HTTP.Paths = append(kubeRule.HTTP.Paths, extensionsv1beta1.HTTPIngressPath{
+			Path:    rule.Path,
+			Backend: *toKubeV1beta1IngressBackend(rule.Backend),
+		})
+	}
+
+	return kubeRules, nil
+}
+
+func toKubeV1beta1IngressBackend(backend *Backend) *extensionsv1beta1.IngressBackend {
+	if backend == nil {
+		return nil
+	}
+
+	return &extensionsv1beta1.IngressBackend{
+		ServiceName: backend.ServiceName,
+		ServicePort: backend.ServicePort,
+	}
+}
@@ -0,0 +1,111 @@
+package job
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	batchv2alpha1 "k8s.io/api/batch/v2alpha1"
+)
+
+// NewJobFromKubeJob will create a new Job object with the data from a
+// provided kubernetes job object
+func NewJobFromKubeJob(obj interface{}) (*Job, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(batchv1.Job{}):
+		o := obj.(batchv1.Job)
+		return fromKubeJobV1(&o)
+	case reflect.TypeOf(&batchv1.Job{}):
+		return fromKubeJobV1(obj.(*batchv1.Job))
+	default:
+		return nil, fmt.Errorf("unknown Job version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeJobV1(kubeJob *batchv1.Job) (*Job, error) {
+	spec, err := fromKubeJobSpecV1(&kubeJob.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	return &Job{
+		Name:        kubeJob.Name,
+		Namespace:   kubeJob.Namespace,
+		Version:     kubeJob.APIVersion,
+		Cluster:     kubeJob.ClusterName,
+		Labels:      kubeJob.Labels,
+		Annotations: kubeJob.Annotations,
+		JobSpec:     *spec,
+	}, nil
+}
+
+// NewJobTemplateFromKubeJobTemplateSpec will create a new JobTemplate
+// object with the data from a provided kubernetes job template object of
+// any supported api version
+func NewJobTemplateFromKubeJobTemplateSpec(obj interface{}) (*JobTemplate, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(batchv1beta1.JobTemplateSpec{}):
+		o := obj.(batchv1beta1.JobTemplateSpec)
+		return fromKubeJobTemplateSpecV1beta1(&o)
+	case reflect.TypeOf(&batchv1beta1.JobTemplateSpec{}):
+		return fromKubeJobTemplateSpecV1beta1(obj.(*batchv1beta1.JobTemplateSpec))
+	case reflect.TypeOf(batchv2alpha1.JobTemplateSpec{}):
+		o := obj.(batchv2alpha1.JobTemplateSpec)
+		return fromKubeJobTemplateSpecV2alpha1(&o)
+	case reflect.TypeOf(&batchv2alpha1.JobTemplateSpec{}):
+		return fromKubeJobTemplateSpecV2alpha1(obj.(*batchv2alpha1.JobTemplateSpec))
+	default:
+		return nil, fmt.Errorf("unknown JobTemplateSpec version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeJobTemplateSpecV1beta1(kubeTemplate *batchv1beta1.JobTemplateSpec) (*JobTemplate, error) {
+	spec, err := fromKubeJobSpecV1(&kubeTemplate.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	return &JobTemplate{
+		Name:        kubeTemplate.Name,
+		Labels:      kubeTemplate.Labels,
+		Annotations: kubeTemplate.Annotations,
+		JobSpec:     *spec,
+	}, nil
+}
+
+func fromKubeJobTemplateSpecV2alpha1(kubeTemplate *batchv2alpha1.JobTemplateSpec) (*JobTemplate, error) {
+	spec, err := fromKubeJobSpecV1(&kubeTemplate.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	return &JobTemplate{
+		Name:        kubeTemplate.Name,
+		Labels:      kubeTemplate.Labels,
+		Annotations: kubeTemplate.Annotations,
+		JobSpec:     *spec,
+	}, nil
+}
+
+func fromKubeJobSpecV1(kubeSpec *batchv1.JobSpec) (*JobSpec, error) {
+	template, err := pod.NewPodTemplateFromKubePodTemplateSpec(&kubeSpec.Template)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.template")
+	}
+
+	return &JobSpec{
+		Parallelism:    kubeSpec.Parallelism,
+		Completions:    kubeSpec.Completions,
+		ActiveDeadline: kubeSpec.ActiveDeadlineSeconds,
+		BackoffLimit:   kubeSpec.BackoffLimit,
+		Selector:       selector.NewLabelSelectorFromKubeLabelSelectorV1(kubeSpec.Selector),
+		ManualSelector: kubeSpec.ManualSelector,
+		Template:       *template,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package job
+
+import (
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+)
+
+// Job defines a job object.  ttlSecondsAfterFinished isn't part of the
+// batch/v1 api this package is built against, so it isn't converted.
+type Job struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	JobSpec
+}
+
+// JobTemplate describes the jobs created by a cron job
+type JobTemplate struct {
+	Name        string            `json:"name,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	JobSpec
+}
+
+type JobSpec struct {
+	Parallelism    *int32                  `json:"parallelism,omitempty"`
+	Completions    *int32                  `json:"completions,omitempty"`
+	ActiveDeadline *int64                  `json:"activeDeadline,omitempty"`
+	BackoffLimit   *int32                  `json:"backoffLimit,omitempty"`
+	Selector       *selector.LabelSelector `json:"selector,omitempty"`
+	ManualSelector *bool                   `json:"manualSelector,omitempty"`
+
+	Template pod.PodTemplate `json:"template"`
+}
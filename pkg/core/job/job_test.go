@@ -0,0 +1,67 @@
+package job
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestJobRoundTrip(t *testing.T) {
+	kubeJob := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migrate",
+			Namespace: "testNS",
+		},
+		Spec: batchv1.JobSpec{
+			Parallelism:           int32Ptr(2),
+			Completions:           int32Ptr(4),
+			ActiveDeadlineSeconds: int64Ptr(600),
+			BackoffLimit:          int32Ptr(3),
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers:    []v1.Container{{Name: "migrate", Image: "migrate"}},
+					RestartPolicy: v1.RestartPolicyOnFailure,
+				},
+			},
+		},
+	}
+
+	j, err := NewJobFromKubeJob(kubeJob)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(j)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	j = &Job{}
+	if err := json.Unmarshal(data, j); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := j.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeJob) {
+		t.Errorf("round trip changed the job\nexpected %#v\ngot      %#v", kubeJob, obj)
+	}
+}
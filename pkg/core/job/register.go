@@ -0,0 +1,30 @@
+package job
+
+import (
+	"mantle/pkg/registry"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "job"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			batchv1.SchemeGroupVersion.WithKind("Job"),
+		},
+		New: func() registry.Object {
+			return &Job{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			j, err := NewJobFromKubeJob(obj)
+			if err != nil {
+				return nil, err
+			}
+			return j, nil
+		},
+	})
+}
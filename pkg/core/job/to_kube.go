@@ -0,0 +1,108 @@
+package job
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	batchv2alpha1 "k8s.io/api/batch/v2alpha1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes job object of the api version type
+// defined in the object
+func (j *Job) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(j.Version) {
+	case "batch/v1":
+		return j.toKubeV1()
+	case "":
+		return j.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for job: %s", j.Version)
+	}
+}
+
+func (j *Job) toKubeV1() (*batchv1.Job, error) {
+	spec, err := j.JobSpec.toKubeV1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeJob := &batchv1.Job{}
+	kubeJob.Name = j.Name
+	kubeJob.Namespace = j.Namespace
+	kubeJob.APIVersion = "batch/v1"
+	kubeJob.ClusterName = j.Cluster
+	kubeJob.Kind = "Job"
+	kubeJob.Labels = j.Labels
+	kubeJob.Annotations = j.Annotations
+	kubeJob.Spec = *spec
+
+	return kubeJob, nil
+}
+
+// ToKube will return a kubernetes job template object of the provided
+// batch api version
+func (t *JobTemplate) ToKube(version string) (interface{}, error) {
+	switch strings.ToLower(version) {
+	case "batch/v1beta1":
+		return t.toKubeV1beta1()
+	case "":
+		return t.toKubeV1beta1()
+	case "batch/v2alpha1":
+		return t.toKubeV2alpha1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for job template: %s", version)
+	}
+}
+
+func (t *JobTemplate) toKubeV1beta1() (*batchv1beta1.JobTemplateSpec, error) {
+	spec, err := t.JobSpec.toKubeV1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeTemplate := &batchv1beta1.JobTemplateSpec{}
+	kubeTemplate.Name = t.Name
+	kubeTemplate.Labels = t.Labels
+	kubeTemplate.Annotations = t.Annotations
+	kubeTemplate.Spec = *spec
+
+	return kubeTemplate, nil
+}
+
+func (t *JobTemplate) toKubeV2alpha1() (*batchv2alpha1.JobTemplateSpec, error) {
+	spec, err := t.JobSpec.toKubeV1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeTemplate := &batchv2alpha1.JobTemplateSpec{}
+	kubeTemplate.Name = t.Name
+	kubeTemplate.Labels = t.Labels
+	kubeTemplate.Annotations = t.Annotations
+	kubeTemplate.Spec = *spec
+
+	return kubeTemplate, nil
+}
+
+func (s *JobSpec) toKubeV1() (*batchv1.JobSpec, error) {
+	template, err := s.Template.ToKube("v1")
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.template")
+	}
+
+	return &batchv1.JobSpec{
+		Parallelism:           s.Parallelism,
+		Completions:           s.Completions,
+		ActiveDeadlineSeconds: s.ActiveDeadline,
+		BackoffLimit:          s.BackoffLimit,
+		Selector:              s.Selector.ToKubeLabelSelectorV1(),
+		ManualSelector:        s.ManualSelector,
+		Template:              *template.(*v1.PodTemplateSpec),
+	}, nil
+}
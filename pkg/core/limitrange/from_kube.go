@@ -0,0 +1,104 @@
+package limitrange
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+)
+
+// NewLimitRangeFromKubeLimitRange will create a new LimitRange object with
+// the data from a provided kubernetes limit range object of any supported
+// api version
+func NewLimitRangeFromKubeLimitRange(obj interface{}) (*LimitRange, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.LimitRange{}):
+		o := obj.(v1.LimitRange)
+		return fromKubeLimitRangeV1(&o)
+	case reflect.TypeOf(&v1.LimitRange{}):
+		return fromKubeLimitRangeV1(obj.(*v1.LimitRange))
+	default:
+		return nil, fmt.Errorf("unknown LimitRange version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeLimitRangeV1(kubeLimitRange *v1.LimitRange) (*LimitRange, error) {
+	var limits []Limit
+	for i, kubeItem := range kubeLimitRange.Spec.Limits {
+		limit, err := fromKubeLimitRangeItemV1(kubeItem)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.spec.limits.%d", i)
+		}
+		limits = append(limits, *limit)
+	}
+
+	return &LimitRange{
+		Name:        kubeLimitRange.Name,
+		Namespace:   kubeLimitRange.Namespace,
+		Version:     kubeLimitRange.APIVersion,
+		Cluster:     kubeLimitRange.ClusterName,
+		Labels:      kubeLimitRange.Labels,
+		Annotations: kubeLimitRange.Annotations,
+		Limits:      limits,
+	}, nil
+}
+
+func fromKubeLimitRangeItemV1(kubeItem v1.LimitRangeItem) (*Limit, error) {
+	limit := &Limit{}
+	switch kubeItem.Type {
+	case v1.LimitTypeContainer:
+		limit.Type = LimitTypeContainer
+	case v1.LimitTypePod:
+		limit.Type = LimitTypePod
+	case v1.LimitTypePersistentVolumeClaim:
+		limit.Type = LimitTypePVC
+	default:
+		return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(kubeItem.Type, "unrecognized limit type"), "$.type")
+	}
+
+	resources := map[v1.ResourceName]*ResourceLimit{}
+	get := func(name v1.ResourceName) *ResourceLimit {
+		if resources[name] == nil {
+			resources[name] = &ResourceLimit{Name: string(name)}
+		}
+		return resources[name]
+	}
+	for name, quantity := range kubeItem.Min {
+		q := quantity
+		get(name).Min = &q
+	}
+	for name, quantity := range kubeItem.Max {
+		q := quantity
+		get(name).Max = &q
+	}
+	for name, quantity := range kubeItem.Default {
+		q := quantity
+		get(name).Default = &q
+	}
+	for name, quantity := range kubeItem.DefaultRequest {
+		q := quantity
+		get(name).DefaultRequest = &q
+	}
+	for name, quantity := range kubeItem.MaxLimitRequestRatio {
+		q := quantity
+		get(name).MaxRatio = &q
+	}
+
+	for _, r := range resources {
+		limit.Resources = append(limit.Resources, *r)
+	}
+	sortResourceLimits(limit.Resources)
+
+	return limit, nil
+}
+
+// sortResourceLimits orders resource limits by name, so that conversions
+// from kubernetes resource lists are stable
+func sortResourceLimits(limits []ResourceLimit) {
+	sort.Slice(limits, func(i, j int) bool {
+		return limits[i].Name < limits[j].Name
+	})
+}
@@ -0,0 +1,236 @@
+package limitrange
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	"github.com/koki/json/jsonutil"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// LimitRange defines a limit range object, which constrains the resources
+// of each container, pod or claim in a namespace
+type LimitRange struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Limits []Limit `json:"limits,omitempty"`
+}
+
+type LimitType string
+
+const (
+	LimitTypeContainer LimitType = "container"
+	LimitTypePod       LimitType = "pod"
+	LimitTypePVC       LimitType = "pvc"
+)
+
+// Limit constrains the resources of one type of object.  It is written as
+// a single entry dictionary from the type to its resource limits, either a
+// single ResourceLimit or a list of them:
+//
+//	container: cpu 100m..2, default 500m
+//	pod: [cpu ..4, memory ..8Gi]
+type Limit struct {
+	Type      LimitType
+	Resources []ResourceLimit
+}
+
+// ResourceLimit constrains one resource.  It is written as the resource
+// name followed by the range of allowed amounts and any of the defaults,
+// separated by commas:
+//
+//	cpu min..max, default 500m, default-request 200m, ratio 4
+//
+// Either end of the range can be left out, and the range itself is
+// optional.  The ratio is the largest allowed limit to request ratio.
+type ResourceLimit struct {
+	Name string
+
+	Min            *resource.Quantity
+	Max            *resource.Quantity
+	Default        *resource.Quantity
+	DefaultRequest *resource.Quantity
+	MaxRatio       *resource.Quantity
+}
+
+const (
+	rangeSeparator  = ".."
+	clauseSeparator = ","
+
+	defaultKeyword        = "default"
+	defaultRequestKeyword = "default-request"
+	ratioKeyword          = "ratio"
+)
+
+func (l *Limit) UnmarshalJSON(data []byte) error {
+	obj := map[string]interface{}{}
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a dictionary with a single limit type")
+	}
+
+	key, val, err := jsonutil.GetOnlyMapEntry(obj)
+	if err != nil {
+		return err
+	}
+
+	var strs []string
+	switch val := val.(type) {
+	case string:
+		strs = []string{val}
+	case []interface{}:
+		for i, item := range val {
+			str, ok := item.(string)
+			if !ok {
+				return serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(item, "expected a resource limit string"), "$.%s.%d", key, i)
+			}
+			strs = append(strs, str)
+		}
+	default:
+		return serrors.InvalidValueErrorf(val, "expected a resource limit or a list of them")
+	}
+
+	*l = Limit{Type: LimitType(key)}
+	for i, str := range strs {
+		r := ResourceLimit{}
+		err = r.Unmarshal(str)
+		if err != nil {
+			if _, ok := val.([]interface{}); ok {
+				return serrors.ContextualizeErrorf(err, "$.%s.%d", key, i)
+			}
+			return serrors.ContextualizeErrorf(err, "$.%s", key)
+		}
+		l.Resources = append(l.Resources, r)
+	}
+
+	return nil
+}
+
+func (l Limit) MarshalJSON() ([]byte, error) {
+	strs := make([]string, len(l.Resources))
+	for i, r := range l.Resources {
+		str, err := r.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		strs[i] = str
+	}
+
+	if len(strs) == 1 {
+		return json.Marshal(map[string]string{string(l.Type): strs[0]})
+	}
+	return json.Marshal(map[string][]string{string(l.Type): strs})
+}
+
+// Unmarshal reads the resource limit shorthand
+func (r *ResourceLimit) Unmarshal(str string) error {
+	*r = ResourceLimit{}
+	for i, clause := range strings.Split(str, clauseSeparator) {
+		fields := strings.Fields(clause)
+		if i == 0 {
+			if len(fields) == 0 || len(fields) > 2 {
+				return serrors.InvalidValueErrorf(str, "expected a resource name and an optional min..max")
+			}
+			r.Name = fields[0]
+			if len(fields) == 2 {
+				err := r.unmarshalRange(fields[1])
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if len(fields) != 2 {
+			return serrors.InvalidValueErrorf(clause, "expected a keyword and an amount")
+		}
+		quantity, err := parseQuantity(fields[1])
+		if err != nil {
+			return err
+		}
+		switch fields[0] {
+		case defaultKeyword:
+			r.Default = quantity
+		case defaultRequestKeyword:
+			r.DefaultRequest = quantity
+		case ratioKeyword:
+			r.MaxRatio = quantity
+		default:
+			return serrors.InvalidValueErrorf(fields[0], "unrecognized keyword, expected %s, %s or %s", defaultKeyword, defaultRequestKeyword, ratioKeyword)
+		}
+	}
+
+	return nil
+}
+
+func (r *ResourceLimit) unmarshalRange(str string) error {
+	bounds := strings.Split(str, rangeSeparator)
+	if len(bounds) != 2 || len(bounds[0])+len(bounds[1]) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected min..max")
+	}
+
+	var err error
+	if len(bounds[0]) > 0 {
+		r.Min, err = parseQuantity(bounds[0])
+		if err != nil {
+			return err
+		}
+	}
+	if len(bounds[1]) > 0 {
+		r.Max, err = parseQuantity(bounds[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Marshal returns the resource limit shorthand
+func (r ResourceLimit) Marshal() (string, error) {
+	if len(r.Name) == 0 || strings.ContainsAny(r.Name, clauseSeparator+" ") {
+		return "", serrors.InvalidValueErrorf(r.Name, "invalid resource name")
+	}
+
+	head := r.Name
+	if r.Min != nil || r.Max != nil {
+		head += " " + quantityString(r.Min) + rangeSeparator + quantityString(r.Max)
+	}
+
+	clauses := []string{head}
+	if r.Default != nil {
+		clauses = append(clauses, defaultKeyword+" "+r.Default.String())
+	}
+	if r.DefaultRequest != nil {
+		clauses = append(clauses, defaultRequestKeyword+" "+r.DefaultRequest.String())
+	}
+	if r.MaxRatio != nil {
+		clauses = append(clauses, ratioKeyword+" "+r.MaxRatio.String())
+	}
+
+	return strings.Join(clauses, clauseSeparator+" "), nil
+}
+
+func quantityString(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return ""
+	}
+
+	return quantity.String()
+}
+
+func parseQuantity(str string) (*resource.Quantity, error) {
+	quantity, err := resource.ParseQuantity(str)
+	if err != nil {
+		return nil, serrors.InvalidValueErrorf(str, "invalid quantity: %s", err)
+	}
+
+	return &quantity, nil
+}
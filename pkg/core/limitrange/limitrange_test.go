@@ -0,0 +1,113 @@
+package limitrange
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLimitShorthand(t *testing.T) {
+	testcases := []struct {
+		str  string
+		pass bool
+	}{
+		{str: `{"container":"cpu 100m..2, default 500m"}`, pass: true},
+		{str: `{"container":"cpu ..2, default 500m, default-request 200m, ratio 4"}`, pass: true},
+		{str: `{"pod":["cpu ..4","memory 64Mi.."]}`, pass: true},
+		{str: `{"pvc":"storage 1Gi..10Gi"}`, pass: true},
+		{str: `{"container":"cpu"}`, pass: true},
+		{str: `{"container":"cpu 2"}`, pass: false},
+		{str: `{"container":"cpu 100m..2, maximum 3"}`, pass: false},
+		{str: `{"container":"cpu .., default 1"}`, pass: false},
+		{str: `{"container":"cpu, default"}`, pass: false},
+		{str: `{"container":3}`, pass: false},
+	}
+
+	for _, tc := range testcases {
+		limit := Limit{}
+		err := json.Unmarshal([]byte(tc.str), &limit)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+			continue
+		}
+		if !tc.pass {
+			continue
+		}
+
+		data, err := json.Marshal(limit)
+		if err != nil {
+			t.Errorf("%s: marshal failed: %v", tc.str, err)
+		} else if string(data) != tc.str {
+			t.Errorf("%s: round trip produced %s", tc.str, data)
+		}
+	}
+}
+
+func TestLimitRangeRoundTrip(t *testing.T) {
+	kubeLimitRange := &v1.LimitRange{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "LimitRange",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "limits",
+			Namespace: "testNS",
+		},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{
+				{
+					Type: v1.LimitTypeContainer,
+					Min: v1.ResourceList{
+						v1.ResourceCPU: resource.MustParse("100m"),
+					},
+					Max: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("2"),
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Default: v1.ResourceList{
+						v1.ResourceCPU: resource.MustParse("500m"),
+					},
+				},
+				{
+					Type: v1.LimitTypePersistentVolumeClaim,
+					Max: v1.ResourceList{
+						v1.ResourceStorage: resource.MustParse("10Gi"),
+					},
+				},
+			},
+		},
+	}
+
+	l, err := NewLimitRangeFromKubeLimitRange(kubeLimitRange)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	expected := `{"version":"v1","name":"limits","namespace":"testNS",` +
+		`"limits":[{"container":["cpu 100m..2, default 500m","memory ..1Gi"]},{"pvc":"storage ..10Gi"}]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	l = &LimitRange{}
+	if err := json.Unmarshal(data, l); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := l.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeLimitRange) {
+		t.Errorf("round trip changed the limit range\nexpected %#v\ngot      %#v", kubeLimitRange, obj)
+	}
+}
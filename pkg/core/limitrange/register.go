@@ -0,0 +1,30 @@
+package limitrange
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "limit_range"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("LimitRange"),
+		},
+		New: func() registry.Object {
+			return &LimitRange{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			l, err := NewLimitRangeFromKubeLimitRange(obj)
+			if err != nil {
+				return nil, err
+			}
+			return l, nil
+		},
+	})
+}
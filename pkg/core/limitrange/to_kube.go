@@ -0,0 +1,82 @@
+package limitrange
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes limit range object of the api version
+// type defined in the object
+func (l *LimitRange) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(l.Version) {
+	case "v1":
+		return l.toKubeV1()
+	case "":
+		return l.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for limit range: %s", l.Version)
+	}
+}
+
+func (l *LimitRange) toKubeV1() (*v1.LimitRange, error) {
+	kubeLimitRange := &v1.LimitRange{}
+	kubeLimitRange.Name = l.Name
+	kubeLimitRange.Namespace = l.Namespace
+	kubeLimitRange.APIVersion = "v1"
+	kubeLimitRange.ClusterName = l.Cluster
+	kubeLimitRange.Kind = "LimitRange"
+	kubeLimitRange.Labels = l.Labels
+	kubeLimitRange.Annotations = l.Annotations
+	for i, limit := range l.Limits {
+		kubeItem, err := limit.toKubeV1()
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.limits.%d", i)
+		}
+		kubeLimitRange.Spec.Limits = append(kubeLimitRange.Spec.Limits, *kubeItem)
+	}
+
+	return kubeLimitRange, nil
+}
+
+func (l Limit) toKubeV1() (*v1.LimitRangeItem, error) {
+	kubeItem := &v1.LimitRangeItem{}
+	switch l.Type {
+	case LimitTypeContainer:
+		kubeItem.Type = v1.LimitTypeContainer
+	case LimitTypePod:
+		kubeItem.Type = v1.LimitTypePod
+	case LimitTypePVC:
+		kubeItem.Type = v1.LimitTypePersistentVolumeClaim
+	default:
+		return nil, serrors.InvalidValueErrorf(l.Type, "unrecognized limit type")
+	}
+
+	for _, r := range l.Resources {
+		name := v1.ResourceName(r.Name)
+		kubeItem.Min = addToResourceList(kubeItem.Min, name, r.Min)
+		kubeItem.Max = addToResourceList(kubeItem.Max, name, r.Max)
+		kubeItem.Default = addToResourceList(kubeItem.Default, name, r.Default)
+		kubeItem.DefaultRequest = addToResourceList(kubeItem.DefaultRequest, name, r.DefaultRequest)
+		kubeItem.MaxLimitRequestRatio = addToResourceList(kubeItem.MaxLimitRequestRatio, name, r.MaxRatio)
+	}
+
+	return kubeItem, nil
+}
+
+func addToResourceList(kubeList v1.ResourceList, name v1.ResourceName, quantity *resource.Quantity) v1.ResourceList {
+	if quantity == nil {
+		return kubeList
+	}
+
+	if kubeList == nil {
+		kubeList = v1.ResourceList{}
+	}
+	kubeList[name] = *quantity
+	return kubeList
+}
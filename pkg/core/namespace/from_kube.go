@@ -0,0 +1,40 @@
+package namespace
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/core/v1"
+)
+
+// NewNamespaceFromKubeNamespace will create a new Namespace object with
+// the data from a provided kubernetes namespace object of any supported api
+// version
+func NewNamespaceFromKubeNamespace(obj interface{}) (*Namespace, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.Namespace{}):
+		o := obj.(v1.Namespace)
+		return fromKubeNamespaceV1(&o)
+	case reflect.TypeOf(&v1.Namespace{}):
+		return fromKubeNamespaceV1(obj.(*v1.Namespace))
+	default:
+		return nil, fmt.Errorf("unknown Namespace version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeNamespaceV1(kubeNamespace *v1.Namespace) (*Namespace, error) {
+	var finalizers []string
+	for _, finalizer := range kubeNamespace.Spec.Finalizers {
+		finalizers = append(finalizers, string(finalizer))
+	}
+
+	return &Namespace{
+		Name:        kubeNamespace.Name,
+		Namespace:   kubeNamespace.Namespace,
+		Version:     kubeNamespace.APIVersion,
+		Cluster:     kubeNamespace.ClusterName,
+		Labels:      kubeNamespace.Labels,
+		Annotations: kubeNamespace.Annotations,
+		Finalizers:  finalizers,
+	}, nil
+}
@@ -0,0 +1,14 @@
+package namespace
+
+// Namespace defines a namespace object.  The status of the namespace is
+// dropped.
+type Namespace struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Finalizers []string `json:"finalizers,omitempty"`
+}
@@ -0,0 +1,57 @@
+package namespace
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceRoundTrip(t *testing.T) {
+	kubeNamespace := &v1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-a",
+			Labels: map[string]string{"team": "a"},
+		},
+		Spec: v1.NamespaceSpec{
+			Finalizers: []v1.FinalizerName{v1.FinalizerKubernetes},
+		},
+		Status: v1.NamespaceStatus{Phase: v1.NamespaceActive},
+	}
+
+	n, err := NewNamespaceFromKubeNamespace(kubeNamespace)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(n)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	expected := `{"version":"v1","name":"team-a","labels":{"team":"a"},"finalizers":["kubernetes"]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	n = &Namespace{}
+	if err := json.Unmarshal(data, n); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := n.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+
+	kubeNamespace.Status = v1.NamespaceStatus{}
+	if !reflect.DeepEqual(obj, kubeNamespace) {
+		t.Errorf("round trip changed the namespace\nexpected %#v\ngot      %#v", kubeNamespace, obj)
+	}
+}
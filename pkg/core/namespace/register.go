@@ -0,0 +1,30 @@
+package namespace
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "namespace"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("Namespace"),
+		},
+		New: func() registry.Object {
+			return &Namespace{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			n, err := NewNamespaceFromKubeNamespace(obj)
+			if err != nil {
+				return nil, err
+			}
+			return n, nil
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package namespace
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes namespace object of the api version type
+// defined in the object
+func (n *Namespace) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(n.Version) {
+	case "v1":
+		return n.toKubeV1()
+	case "":
+		return n.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for namespace: %s", n.Version)
+	}
+}
+
+func (n *Namespace) toKubeV1() (*v1.Namespace, error) {
+	kubeNamespace := &v1.Namespace{}
+	kubeNamespace.Name = n.Name
+	kubeNamespace.Namespace = n.Namespace
+	kubeNamespace.APIVersion = "v1"
+	kubeNamespace.ClusterName = n.Cluster
+	kubeNamespace.Kind = "Namespace"
+	kubeNamespace.Labels = n.Labels
+	kubeNamespace.Annotations = n.Annotations
+	for _, finalizer := range n.Finalizers {
+		kubeNamespace.Spec.Finalizers = append(kubeNamespace.Spec.Finalizers, v1.FinalizerName(finalizer))
+	}
+
+	return kubeNamespace, nil
+}
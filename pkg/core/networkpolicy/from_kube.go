@@ -0,0 +1,138 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewNetworkPolicyFromKubeNetworkPolicy will create a new NetworkPolicy
+// object with the data from a provided kubernetes network policy object
+func NewNetworkPolicyFromKubeNetworkPolicy(obj interface{}) (*NetworkPolicy, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(networkingv1.NetworkPolicy{}):
+		o := obj.(networkingv1.NetworkPolicy)
+		return fromKubeNetworkPolicyV1(&o)
+	case reflect.TypeOf(&networkingv1.NetworkPolicy{}):
+		return fromKubeNetworkPolicyV1(obj.(*networkingv1.NetworkPolicy))
+	default:
+		return nil, fmt.Errorf("unknown NetworkPolicy version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeNetworkPolicyV1(kubePolicy *networkingv1.NetworkPolicy) (*NetworkPolicy, error) {
+	policy := &NetworkPolicy{
+		Name:        kubePolicy.Name,
+		Namespace:   kubePolicy.Namespace,
+		Version:     kubePolicy.APIVersion,
+		Cluster:     kubePolicy.ClusterName,
+		Labels:      kubePolicy.Labels,
+		Annotations: kubePolicy.Annotations,
+	}
+
+	if !reflect.DeepEqual(kubePolicy.Spec.PodSelector, metav1.LabelSelector{}) {
+		policy.Pods = selector.NewLabelSelectorFromKubeLabelSelectorV1(&kubePolicy.Spec.PodSelector)
+	}
+
+	// Without policy types, kubernetes restricts ingress, and egress
+	// only if there are egress rules
+	hasIngress, hasEgress := len(kubePolicy.Spec.PolicyTypes) == 0, len(kubePolicy.Spec.Egress) > 0
+	for i, policyType := range kubePolicy.Spec.PolicyTypes {
+		switch policyType {
+		case networkingv1.PolicyTypeIngress:
+			hasIngress = true
+		case networkingv1.PolicyTypeEgress:
+			hasEgress = true
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(policyType, "unrecognized policy type"), "$.spec.policyTypes.%d", i)
+		}
+	}
+	if !hasIngress && len(kubePolicy.Spec.Ingress) > 0 {
+		return nil, serrors.InvalidInstanceErrorf(kubePolicy, "ingress rules are set but the policy types don't include %s", networkingv1.PolicyTypeIngress)
+	}
+	if !hasEgress && len(kubePolicy.Spec.Egress) > 0 {
+		return nil, serrors.InvalidInstanceErrorf(kubePolicy, "egress rules are set but the policy types don't include %s", networkingv1.PolicyTypeEgress)
+	}
+
+	if hasIngress {
+		rules := []IngressRule{}
+		for i, kubeRule := range kubePolicy.Spec.Ingress {
+			from, err := fromKubePeersV1(kubeRule.From)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.spec.ingress.%d.from", i)
+			}
+			ports, err := fromKubePortsV1(kubeRule.Ports)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.spec.ingress.%d.ports", i)
+			}
+			rules = append(rules, IngressRule{From: from, Ports: ports})
+		}
+		policy.Ingress = &rules
+	}
+
+	if hasEgress {
+		rules := []EgressRule{}
+		for i, kubeRule := range kubePolicy.Spec.Egress {
+			to, err := fromKubePeersV1(kubeRule.To)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.spec.egress.%d.to", i)
+			}
+			ports, err := fromKubePortsV1(kubeRule.Ports)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.spec.egress.%d.ports", i)
+			}
+			rules = append(rules, EgressRule{To: to, Ports: ports})
+		}
+		policy.Egress = &rules
+	}
+
+	return policy, nil
+}
+
+func fromKubePeersV1(kubePeers []networkingv1.NetworkPolicyPeer) ([]Peer, error) {
+	var peers []Peer
+	for i, kubePeer := range kubePeers {
+		p := Peer{
+			Pods:       selector.NewLabelSelectorFromKubeLabelSelectorV1(kubePeer.PodSelector),
+			Namespaces: selector.NewLabelSelectorFromKubeLabelSelectorV1(kubePeer.NamespaceSelector),
+		}
+		if kubePeer.IPBlock != nil {
+			if p.Pods != nil || p.Namespaces != nil {
+				return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(kubePeer, "a peer can't select both pods and an ip block"), "$.%d", i)
+			}
+			p.CIDR = kubePeer.IPBlock.CIDR
+			p.Except = kubePeer.IPBlock.Except
+		}
+		peers = append(peers, p)
+	}
+
+	return peers, nil
+}
+
+func fromKubePortsV1(kubePorts []networkingv1.NetworkPolicyPort) ([]Port, error) {
+	var ports []Port
+	for i, kubePort := range kubePorts {
+		port := Port{Port: kubePort.Port}
+		if kubePort.Protocol != nil {
+			switch *kubePort.Protocol {
+			case v1.ProtocolTCP:
+				port.Protocol = pod.ProtocolTCP
+			case v1.ProtocolUDP:
+				port.Protocol = pod.ProtocolUDP
+			default:
+				return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(*kubePort.Protocol, "unrecognized protocol"), "$.%d", i)
+			}
+		}
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
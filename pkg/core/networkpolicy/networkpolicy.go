@@ -0,0 +1,40 @@
+package networkpolicy
+
+import (
+	"mantle/internal/pkg/core/selector"
+)
+
+// NetworkPolicy defines a network policy object.  The policy applies to
+// the pods matching Pods, or to every pod in the namespace if it's left
+// out.
+//
+// The policy types are inferred from the sections that are present: a
+// policy with an ingress section restricts ingress and one with an egress
+// section restricts egress.  An empty section, e.g. "ingress: []", denies
+// all traffic in that direction.
+type NetworkPolicy struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Pods    *selector.LabelSelector `json:"pods,omitempty"`
+	Ingress *[]IngressRule          `json:"ingress,omitempty"`
+	Egress  *[]EgressRule           `json:"egress,omitempty"`
+}
+
+// IngressRule allows traffic from any of the peers to any of the ports.
+// Leaving out the peers or the ports allows every peer or port.
+type IngressRule struct {
+	From  []Peer `json:"from,omitempty"`
+	Ports []Port `json:"ports,omitempty"`
+}
+
+// EgressRule allows traffic to any of the peers on any of the ports.
+// Leaving out the peers or the ports allows every peer or port.
+type EgressRule struct {
+	To    []Peer `json:"to,omitempty"`
+	Ports []Port `json:"ports,omitempty"`
+}
@@ -0,0 +1,127 @@
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNetworkPolicyRoundTrip(t *testing.T) {
+	tcp := v1.ProtocolTCP
+	https := intstr.FromInt(443)
+	kubePolicy := &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "testNS",
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "lb"}}},
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+						{
+							PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"role": "monitor"}},
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &tcp, Port: &https},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+	}
+
+	p, err := NewNetworkPolicyFromKubeNetworkPolicy(kubePolicy)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	expected := `{"version":"networking.k8s.io/v1","name":"web","namespace":"testNS","pods":"app=web",` +
+		`"ingress":[{"from":["pods:app=lb","ns:team=a",{"pods":"role=monitor","ns":""},"cidr:10.0.0.0/8 except 10.1.0.0/16"],"ports":["tcp:443"]}],` +
+		`"egress":[]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	p = &NetworkPolicy{}
+	if err := json.Unmarshal(data, p); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := p.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubePolicy) {
+		t.Errorf("round trip changed the network policy\nexpected %#v\ngot      %#v", kubePolicy, obj)
+	}
+}
+
+func TestNetworkPolicyDefaultPolicyTypes(t *testing.T) {
+	kubePolicy := &networkingv1.NetworkPolicy{
+		Spec: networkingv1.NetworkPolicySpec{
+			Egress: []networkingv1.NetworkPolicyEgressRule{{}},
+		},
+	}
+
+	p, err := NewNetworkPolicyFromKubeNetworkPolicy(kubePolicy)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+	if p.Ingress == nil || len(*p.Ingress) != 0 || p.Egress == nil || len(*p.Egress) != 1 {
+		t.Errorf("expected an empty ingress section and one egress rule, got %#v", p)
+	}
+}
+
+func TestPortShorthand(t *testing.T) {
+	testcases := []struct {
+		str  string
+		pass bool
+	}{
+		{str: `"tcp:443"`, pass: true},
+		{str: `"udp:dns"`, pass: true},
+		{str: `"8080"`, pass: true},
+		{str: `"udp"`, pass: true},
+		{str: `"sctp:9000"`, pass: false},
+	}
+
+	for _, tc := range testcases {
+		port := Port{}
+		err := json.Unmarshal([]byte(tc.str), &port)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+			continue
+		}
+		if !tc.pass {
+			continue
+		}
+
+		data, err := json.Marshal(port)
+		if err != nil {
+			t.Errorf("%s: marshal failed: %v", tc.str, err)
+		} else if string(data) != tc.str {
+			t.Errorf("%s: round trip produced %s", tc.str, data)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package networkpolicy
+
+import (
+	"strings"
+
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	PeerTypePods       = "pods"
+	PeerTypeNamespaces = "ns"
+	PeerTypeCIDR       = "cidr"
+
+	peerSeparator   = ":"
+	exceptSeparator = " except "
+	listSeparator   = ","
+)
+
+// Peer is a set of pods or ip addresses that traffic is allowed from or to.
+// It is written as one of
+//
+//	"pods:app=web"   pods matching the selector in the policy's namespace
+//	"ns:team=a"      every pod in the namespaces matching the selector
+//	"cidr:10.0.0.0/8 except 10.1.0.0/16,10.2.0.0/16"
+//
+// An empty selector, e.g. "ns:", matches everything.  Pods matching a
+// selector in the namespaces matching another are written as a dictionary,
+// e.g. {pods: app=web, ns: team=a}.
+type Peer struct {
+	Pods       *selector.LabelSelector `json:"pods,omitempty"`
+	Namespaces *selector.LabelSelector `json:"ns,omitempty"`
+	CIDR       string                  `json:"-"`
+	Except     []string                `json:"-"`
+}
+
+// peer has the fields of Peer without its json methods
+type peer Peer
+
+func (p *Peer) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		return p.Unmarshal(str)
+	}
+
+	obj := peer{}
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected either a peer string or dictionary")
+	}
+
+	*p = Peer(obj)
+	return nil
+}
+
+// Unmarshal reads the "type:value" shorthand
+func (p *Peer) Unmarshal(str string) error {
+	segments := strings.SplitN(str, peerSeparator, 2)
+	if len(segments) != 2 {
+		return serrors.InvalidValueErrorf(str, "expected %s:selector, %s:selector or %s:cidr", PeerTypePods, PeerTypeNamespaces, PeerTypeCIDR)
+	}
+
+	*p = Peer{}
+	switch segments[0] {
+	case PeerTypePods, PeerTypeNamespaces:
+		s := &selector.LabelSelector{}
+		err := s.Unmarshal(segments[1])
+		if err != nil {
+			return err
+		}
+		if segments[0] == PeerTypePods {
+			p.Pods = s
+		} else {
+			p.Namespaces = s
+		}
+	case PeerTypeCIDR:
+		cidr := strings.SplitN(segments[1], exceptSeparator, 2)
+		p.CIDR = strings.TrimSpace(cidr[0])
+		if len(p.CIDR) == 0 {
+			return serrors.InvalidValueErrorf(str, "expected a cidr")
+		}
+		if len(cidr) > 1 {
+			for _, except := range strings.Split(cidr[1], listSeparator) {
+				p.Except = append(p.Except, strings.TrimSpace(except))
+			}
+		}
+	default:
+		return serrors.InvalidValueErrorf(segments[0], "unrecognized peer type, expected %s, %s or %s", PeerTypePods, PeerTypeNamespaces, PeerTypeCIDR)
+	}
+
+	return nil
+}
+
+func (p Peer) MarshalJSON() ([]byte, error) {
+	if p.Pods != nil && p.Namespaces != nil {
+		if len(p.CIDR) > 0 {
+			return nil, serrors.InvalidInstanceErrorf(p, "a peer can't select both pods and a cidr")
+		}
+		return json.Marshal(peer(p))
+	}
+
+	str, err := p.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(str)
+}
+
+// Marshal returns the "type:value" shorthand for a peer that selects only
+// one of pods, namespaces or a cidr
+func (p Peer) Marshal() (string, error) {
+	set := 0
+	peerType, value := "", ""
+	if p.Pods != nil {
+		str, err := p.Pods.Marshal()
+		if err != nil {
+			return "", err
+		}
+		set, peerType, value = set+1, PeerTypePods, str
+	}
+	if p.Namespaces != nil {
+		str, err := p.Namespaces.Marshal()
+		if err != nil {
+			return "", err
+		}
+		set, peerType, value = set+1, PeerTypeNamespaces, str
+	}
+	if len(p.CIDR) > 0 {
+		value = p.CIDR
+		if len(p.Except) > 0 {
+			value += exceptSeparator + strings.Join(p.Except, listSeparator)
+		}
+		set, peerType = set+1, PeerTypeCIDR
+	}
+
+	if set != 1 {
+		return "", serrors.InvalidInstanceErrorf(p, "a peer must select one of pods, namespaces or a cidr")
+	}
+
+	return peerType + peerSeparator + value, nil
+}
+
+// Port is a port that traffic is allowed on.  It is written as
+// "protocol:port", e.g. "tcp:443" or "udp:dns", just the port for tcp,
+// e.g. "443", or just the protocol for every port, e.g. "udp".
+type Port struct {
+	Protocol pod.Protocol
+	Port     *intstr.IntOrString
+}
+
+func (p *Port) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		// Plain port numbers are accepted as well
+		num := 0
+		if json.Unmarshal(data, &num) != nil {
+			return serrors.InvalidValueErrorf(string(data), "expected a port string or number")
+		}
+		port := intstr.FromInt(num)
+		*p = Port{Port: &port}
+		return nil
+	}
+
+	return p.Unmarshal(str)
+}
+
+// Unmarshal reads the "protocol:port" shorthand
+func (p *Port) Unmarshal(str string) error {
+	*p = Port{}
+
+	portStr := str
+	segments := strings.SplitN(str, peerSeparator, 2)
+	if len(segments) == 2 {
+		p.Protocol, portStr = pod.Protocol(segments[0]), segments[1]
+	} else if protocol := pod.Protocol(str); protocol == pod.ProtocolTCP || protocol == pod.ProtocolUDP {
+		p.Protocol, portStr = protocol, ""
+	}
+
+	switch p.Protocol {
+	case pod.ProtocolUnset, pod.ProtocolTCP, pod.ProtocolUDP:
+	default:
+		return serrors.InvalidValueErrorf(p.Protocol, "unsupported protocol, expected %s or %s", pod.ProtocolTCP, pod.ProtocolUDP)
+	}
+
+	if len(portStr) > 0 {
+		port := intstr.Parse(portStr)
+		p.Port = &port
+	}
+
+	return nil
+}
+
+func (p Port) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Marshal())
+}
+
+// Marshal returns the "protocol:port" shorthand for the port
+func (p Port) Marshal() string {
+	if p.Port == nil {
+		return string(p.Protocol)
+	}
+	if len(p.Protocol) == 0 {
+		return p.Port.String()
+	}
+
+	return string(p.Protocol) + peerSeparator + p.Port.String()
+}
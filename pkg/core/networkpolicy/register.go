@@ -0,0 +1,30 @@
+package networkpolicy
+
+import (
+	"mantle/pkg/registry"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "network_policy"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"),
+		},
+		New: func() registry.Object {
+			return &NetworkPolicy{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			p, err := NewNetworkPolicyFromKubeNetworkPolicy(obj)
+			if err != nil {
+				return nil, err
+			}
+			return p, nil
+		},
+	})
+}
@@ -0,0 +1,133 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes network policy object of the api
+// version type defined in the object
+func (p *NetworkPolicy) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(p.Version) {
+	case "networking.k8s.io/v1":
+		return p.toKubeV1()
+	case "":
+		return p.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for network policy: %s", p.Version)
+	}
+}
+
+func (p *NetworkPolicy) toKubeV1() (*networkingv1.NetworkPolicy, error) {
+	kubePolicy := &networkingv1.NetworkPolicy{}
+	kubePolicy.Name = p.Name
+	kubePolicy.Namespace = p.Namespace
+	kubePolicy.APIVersion = "networking.k8s.io/v1"
+	kubePolicy.ClusterName = p.Cluster
+	kubePolicy.Kind = "NetworkPolicy"
+	kubePolicy.Labels = p.Labels
+	kubePolicy.Annotations = p.Annotations
+
+	if p.Pods != nil {
+		kubePolicy.Spec.PodSelector = *p.Pods.ToKubeLabelSelectorV1()
+	}
+
+	if p.Ingress != nil {
+		kubePolicy.Spec.PolicyTypes = append(kubePolicy.Spec.PolicyTypes, networkingv1.PolicyTypeIngress)
+		for i, rule := range *p.Ingress {
+			from, err := toKubePeersV1(rule.From)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.ingress.%d.from", i)
+			}
+			ports, err := toKubePortsV1(rule.Ports)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.ingress.%d.ports", i)
+			}
+			kubePolicy.Spec.Ingress = append(kubePolicy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+				From:  from,
+				Ports: ports,
+			})
+		}
+	}
+
+	if p.Egress != nil {
+		kubePolicy.Spec.PolicyTypes = append(kubePolicy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		for i, rule := range *p.Egress {
+			to, err := toKubePeersV1(rule.To)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.egress.%d.to", i)
+			}
+			ports, err := toKubePortsV1(rule.Ports)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.egress.%d.ports", i)
+			}
+			kubePolicy.Spec.Egress = append(kubePolicy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+				To:    to,
+				Ports: ports,
+			})
+		}
+	}
+
+	if len(kubePolicy.Spec.PolicyTypes) == 0 {
+		return nil, serrors.InvalidInstanceErrorf(p, "a network policy needs an ingress or egress section")
+	}
+
+	return kubePolicy, nil
+}
+
+func toKubePeersV1(peers []Peer) ([]networkingv1.NetworkPolicyPeer, error) {
+	var kubePeers []networkingv1.NetworkPolicyPeer
+	for i, peer := range peers {
+		kubePeer := networkingv1.NetworkPolicyPeer{
+			PodSelector:       peer.Pods.ToKubeLabelSelectorV1(),
+			NamespaceSelector: peer.Namespaces.ToKubeLabelSelectorV1(),
+		}
+		if len(peer.CIDR) > 0 {
+			if peer.Pods != nil || peer.Namespaces != nil {
+				return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(peer, "a peer can't select both pods and a cidr"), "$.%d", i)
+			}
+			kubePeer.IPBlock = &networkingv1.IPBlock{
+				CIDR:   peer.CIDR,
+				Except: peer.Except,
+			}
+		} else if len(peer.Except) > 0 {
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(peer, "except is only allowed with a cidr"), "$.%d", i)
+		}
+		kubePeers = append(kubePeers, kubePeer)
+	}
+
+	return kubePeers, nil
+}
+
+func toKubePortsV1(ports []Port) ([]networkingv1.NetworkPolicyPort, error) {
+	var kubePorts []networkingv1.NetworkPolicyPort
+	for i, port := range ports {
+		kubePort := networkingv1.NetworkPolicyPort{Port: port.Port}
+
+		var protocol v1.Protocol
+		switch port.Protocol {
+		case pod.ProtocolUnset:
+		case pod.ProtocolTCP:
+			protocol = v1.ProtocolTCP
+		case pod.ProtocolUDP:
+			protocol = v1.ProtocolUDP
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(port.Protocol, "unrecognized protocol"), "$.%d", i)
+		}
+		if len(protocol) > 0 {
+			kubePort.Protocol = &protocol
+		}
+
+		kubePorts = append(kubePorts, kubePort)
+	}
+
+	return kubePorts, nil
+}
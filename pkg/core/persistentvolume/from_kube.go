@@ -0,0 +1,181 @@
+package persistentvolume
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pvc"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NewPersistentVolumeFromKubePersistentVolume will create a new
+// PersistentVolume object with the data from a provided kubernetes
+// persistent volume object
+func NewPersistentVolumeFromKubePersistentVolume(obj interface{}) (*PersistentVolume, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.PersistentVolume{}):
+		o := obj.(v1.PersistentVolume)
+		return fromKubePersistentVolumeV1(&o)
+	case reflect.TypeOf(&v1.PersistentVolume{}):
+		return fromKubePersistentVolumeV1(obj.(*v1.PersistentVolume))
+	default:
+		return nil, fmt.Errorf("unknown PersistentVolume version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubePersistentVolumeV1(kubePV *v1.PersistentVolume) (*PersistentVolume, error) {
+	source, err := fromKubePersistentVolumeSourceV1(&kubePV.Spec.PersistentVolumeSource)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	secretNamespace, err := fromKubeSecretNamespaceV1(&kubePV.Spec.PersistentVolumeSource)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	capacity, err := fromKubeCapacityV1(kubePV.Spec.Capacity)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.capacity")
+	}
+
+	accessModes, err := pvc.NewAccessModesFromKubeAccessModes(kubePV.Spec.AccessModes)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.accessModes")
+	}
+
+	reclaimPolicy, err := NewReclaimPolicyFromKubeReclaimPolicy(kubePV.Spec.PersistentVolumeReclaimPolicy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.persistentVolumeReclaimPolicy")
+	}
+
+	volumeMode, err := pvc.NewVolumeModeFromKubeVolumeMode(kubePV.Spec.VolumeMode)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.volumeMode")
+	}
+
+	pv := &PersistentVolume{
+		Name:            kubePV.Name,
+		Namespace:       kubePV.Namespace,
+		Version:         kubePV.APIVersion,
+		Cluster:         kubePV.ClusterName,
+		Labels:          kubePV.Labels,
+		Annotations:     kubePV.Annotations,
+		Source:          *source,
+		SecretNamespace: secretNamespace,
+		Capacity:        capacity,
+		AccessModes:     accessModes,
+		ReclaimPolicy:   reclaimPolicy,
+		StorageClass:    kubePV.Spec.StorageClassName,
+		MountOptions:    kubePV.Spec.MountOptions,
+		VolumeMode:      volumeMode,
+		ClaimRef:        kubePV.Spec.ClaimRef,
+	}
+
+	if kubePV.Spec.NodeAffinity != nil {
+		pv.NodeAffinity = kubePV.Spec.NodeAffinity.Required
+	}
+
+	return pv, nil
+}
+
+// fromKubePersistentVolumeSourceV1 converts the source through the pod
+// volume of the same type, which has the same fields except for the
+// namespace of referenced secrets
+func fromKubePersistentVolumeSourceV1(kubeSource *v1.PersistentVolumeSource) (*Source, error) {
+	if kubeSource.Local != nil || kubeSource.CSI != nil {
+		if !reflect.DeepEqual(*kubeSource, v1.PersistentVolumeSource{Local: kubeSource.Local, CSI: kubeSource.CSI}) || kubeSource.Local != nil && kubeSource.CSI != nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeSource, "has more than one volume source")
+		}
+		if kubeSource.Local != nil {
+			return &Source{Local: &LocalSource{Path: kubeSource.Local.Path}}, nil
+		}
+		csi := CSISource(*kubeSource.CSI)
+		return &Source{CSI: &csi}, nil
+	}
+
+	kubeVolume := &v1.Volume{}
+	err := converterutils.ConvertKubeObject(kubeSource, &kubeVolume.VolumeSource)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeSource, "couldn't convert to a pod volume: %s", err)
+	}
+
+	volume, err := pod.NewVolumeFromKubeVolume(kubeVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Source{Volume: volume}, nil
+}
+
+// fromKubeSecretNamespaceV1 returns the namespace of the secret referenced
+// by the volume source, if any
+func fromKubeSecretNamespaceV1(kubeSource *v1.PersistentVolumeSource) (string, error) {
+	var secretRef *v1.SecretReference
+	switch {
+	case kubeSource.RBD != nil:
+		secretRef = kubeSource.RBD.SecretRef
+	case kubeSource.ISCSI != nil:
+		secretRef = kubeSource.ISCSI.SecretRef
+	case kubeSource.CephFS != nil:
+		secretRef = kubeSource.CephFS.SecretRef
+	case kubeSource.FlexVolume != nil:
+		secretRef = kubeSource.FlexVolume.SecretRef
+	case kubeSource.ScaleIO != nil:
+		secretRef = kubeSource.ScaleIO.SecretRef
+	case kubeSource.AzureFile != nil:
+		if kubeSource.AzureFile.SecretNamespace != nil {
+			return *kubeSource.AzureFile.SecretNamespace, nil
+		}
+	case kubeSource.StorageOS != nil:
+		ref := kubeSource.StorageOS.SecretRef
+		if ref != nil {
+			if *ref != (v1.ObjectReference{Name: ref.Name, Namespace: ref.Namespace}) {
+				return "", serrors.InvalidInstanceErrorf(ref, "only the name and namespace of the secret can be set")
+			}
+			return ref.Namespace, nil
+		}
+	}
+
+	if secretRef == nil {
+		return "", nil
+	}
+	return secretRef.Namespace, nil
+}
+
+func fromKubeCapacityV1(kubeCapacity v1.ResourceList) (*resource.Quantity, error) {
+	for name := range kubeCapacity {
+		if name != v1.ResourceStorage {
+			return nil, serrors.InvalidValueErrorf(name, "only %s capacity is supported", v1.ResourceStorage)
+		}
+	}
+
+	if quantity, ok := kubeCapacity[v1.ResourceStorage]; ok {
+		return &quantity, nil
+	}
+
+	return nil, nil
+}
+
+// NewReclaimPolicyFromKubeReclaimPolicy converts the reclaim policy of a
+// kubernetes persistent volume or storage class
+func NewReclaimPolicyFromKubeReclaimPolicy(kubePolicy v1.PersistentVolumeReclaimPolicy) (ReclaimPolicy, error) {
+	switch kubePolicy {
+	case "":
+		return ReclaimPolicyUnset, nil
+	case v1.PersistentVolumeReclaimRetain:
+		return ReclaimPolicyRetain, nil
+	case v1.PersistentVolumeReclaimRecycle:
+		return ReclaimPolicyRecycle, nil
+	case v1.PersistentVolumeReclaimDelete:
+		return ReclaimPolicyDelete, nil
+	default:
+		return ReclaimPolicyUnset, serrors.InvalidValueErrorf(kubePolicy, "unrecognized reclaim policy")
+	}
+}
@@ -0,0 +1,41 @@
+package persistentvolume
+
+import (
+	"mantle/pkg/core/pvc"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PersistentVolume defines a persistent volume object.  The status of the
+// volume is dropped.
+type PersistentVolume struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Source Source `json:"source"`
+	// SecretNamespace is the namespace of the secret referenced by the
+	// source, which persistent volumes can set unlike pod volumes
+	SecretNamespace string              `json:"secretNamespace,omitempty"`
+	Capacity        *resource.Quantity  `json:"capacity,omitempty"`
+	AccessModes     pvc.AccessModes     `json:"accessModes,omitempty"`
+	ReclaimPolicy   ReclaimPolicy       `json:"reclaimPolicy,omitempty"`
+	StorageClass    string              `json:"storageClass,omitempty"`
+	MountOptions    []string            `json:"mountOptions,omitempty"`
+	VolumeMode      pvc.VolumeMode      `json:"volumeMode,omitempty"`
+	ClaimRef        *v1.ObjectReference `json:"claimRef,omitempty"`
+	NodeAffinity    *v1.NodeSelector    `json:"nodeAffinity,omitempty"`
+}
+
+type ReclaimPolicy string
+
+const (
+	ReclaimPolicyUnset   ReclaimPolicy = ""
+	ReclaimPolicyRetain  ReclaimPolicy = "retain"
+	ReclaimPolicyRecycle ReclaimPolicy = "recycle"
+	ReclaimPolicyDelete  ReclaimPolicy = "delete"
+)
@@ -0,0 +1,121 @@
+package persistentvolume
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPersistentVolumeRoundTrip(t *testing.T) {
+	testcases := []struct {
+		description string
+		source      v1.PersistentVolumeSource
+	}{
+		{
+			description: "nfs",
+			source: v1.PersistentVolumeSource{
+				NFS: &v1.NFSVolumeSource{Server: "nfs.example.com", Path: "/export", ReadOnly: true},
+			},
+		},
+		{
+			description: "rbd with secret namespace",
+			source: v1.PersistentVolumeSource{
+				RBD: &v1.RBDPersistentVolumeSource{
+					CephMonitors: []string{"10.0.0.1:6789"},
+					RBDImage:     "image",
+					RBDPool:      "pool",
+					SecretRef:    &v1.SecretReference{Name: "ceph", Namespace: "storage"},
+				},
+			},
+		},
+		{
+			description: "local",
+			source: v1.PersistentVolumeSource{
+				Local: &v1.LocalVolumeSource{Path: "/mnt/disks/ssd1"},
+			},
+		},
+		{
+			description: "csi",
+			source: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{
+					Driver:       "csi.example.com",
+					VolumeHandle: "vol-1",
+					FSType:       "ext4",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		kubePV := &v1.PersistentVolume{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "PersistentVolume",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "data",
+			},
+			Spec: v1.PersistentVolumeSpec{
+				PersistentVolumeSource: tc.source,
+				Capacity: v1.ResourceList{
+					v1.ResourceStorage: resource.MustParse("10Gi"),
+				},
+				AccessModes:                   []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce, v1.ReadOnlyMany},
+				PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimRetain,
+				StorageClassName:              "fast",
+				MountOptions:                  []string{"hard", "nfsvers=4.1"},
+				NodeAffinity: &v1.VolumeNodeAffinity{
+					Required: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{
+							{
+								MatchExpressions: []v1.NodeSelectorRequirement{
+									{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+		}
+
+		pv, err := NewPersistentVolumeFromKubePersistentVolume(kubePV)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", tc.description, err)
+		}
+
+		data, err := json.Marshal(pv)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", tc.description, err)
+		}
+		pv = &PersistentVolume{}
+		if err := json.Unmarshal(data, pv); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", tc.description, data, err)
+		}
+
+		obj, err := pv.ToKube()
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", tc.description, err)
+		}
+
+		kubePV.Status = v1.PersistentVolumeStatus{}
+		if !reflect.DeepEqual(obj, kubePV) {
+			t.Errorf("%s: round trip changed the persistent volume\nexpected %#v\ngot      %#v", tc.description, kubePV, obj)
+		}
+	}
+}
+
+func TestPersistentVolumePodOnlySource(t *testing.T) {
+	pv := &PersistentVolume{}
+	if err := json.Unmarshal([]byte(`{"source": "empty_dir"}`), pv); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if _, err := pv.ToKube(); err == nil {
+		t.Errorf("expected a pod only volume type to be rejected")
+	}
+}
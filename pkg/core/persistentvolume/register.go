@@ -0,0 +1,30 @@
+package persistentvolume
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "persistent_volume"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("PersistentVolume"),
+		},
+		New: func() registry.Object {
+			return &PersistentVolume{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			pv, err := NewPersistentVolumeFromKubePersistentVolume(obj)
+			if err != nil {
+				return nil, err
+			}
+			return pv, nil
+		},
+	})
+}
@@ -0,0 +1,86 @@
+package persistentvolume
+
+import (
+	. "mantle/internal/marshal"
+	"mantle/pkg/core/pod"
+
+	"github.com/koki/json"
+	"github.com/koki/json/jsonutil"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+)
+
+const (
+	VolumeTypeLocal = "local"
+	VolumeTypeCSI   = "csi"
+)
+
+// Source is the storage backing a persistent volume.  It is written like a
+// pod volume, e.g. "nfs:server:/export" or "aws_ebs:vol-id", and also
+// accepts volumes that only persistent volumes support: "local:path" and
+// a csi dictionary with "vol_type: csi".
+type Source struct {
+	Volume *pod.Volume
+	Local  *LocalSource
+	CSI    *CSISource
+}
+
+type LocalSource struct {
+	Path string
+}
+
+// CSISource has the fields of a kubernetes csi volume, secrets are
+// referenced with their name and namespace
+type CSISource v1.CSIPersistentVolumeSource
+
+func (s *Source) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		segments, err := SplitSelector(str)
+		if err != nil {
+			return err
+		}
+		if segments[0] == VolumeTypeLocal {
+			if len(segments) != 2 {
+				return serrors.InvalidValueErrorf(str, "expected %s:path", VolumeTypeLocal)
+			}
+			s.Local = &LocalSource{Path: segments[1]}
+			return nil
+		}
+	} else {
+		obj := map[string]interface{}{}
+		err = json.Unmarshal(data, &obj)
+		if err != nil {
+			return serrors.InvalidValueErrorf(string(data), "expected either string or dictionary")
+		}
+
+		if volType, _ := obj["vol_type"].(string); volType == VolumeTypeCSI {
+			delete(obj, "vol_type")
+			s.CSI = &CSISource{}
+			return jsonutil.UnmarshalMap(obj, s.CSI)
+		}
+	}
+
+	s.Volume = &pod.Volume{}
+	return json.Unmarshal(data, s.Volume)
+}
+
+func (s Source) MarshalJSON() ([]byte, error) {
+	switch {
+	case s.Local != nil:
+		return json.Marshal(JoinSelector([]string{VolumeTypeLocal, s.Local.Path}))
+	case s.CSI != nil:
+		obj, err := jsonutil.MarshalMap(v1.CSIPersistentVolumeSource(*s.CSI))
+		if err != nil {
+			return nil, err
+		}
+		obj["vol_type"] = VolumeTypeCSI
+		return json.Marshal(obj)
+	case s.Volume != nil:
+		return json.Marshal(s.Volume)
+	default:
+		return nil, serrors.InvalidInstanceErrorf(s, "empty volume source")
+	}
+}
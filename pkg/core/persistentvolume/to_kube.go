@@ -0,0 +1,177 @@
+package persistentvolume
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"mantle/internal/converterutils"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes persistent volume object of the api
+// version type defined in the object
+func (pv *PersistentVolume) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(pv.Version) {
+	case "v1":
+		return pv.toKubeV1()
+	case "":
+		return pv.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for persistent volume: %s", pv.Version)
+	}
+}
+
+func (pv *PersistentVolume) toKubeV1() (*v1.PersistentVolume, error) {
+	source, err := pv.Source.toKubeV1()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.source")
+	}
+
+	err = pv.toKubeV1SecretNamespace(source)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.secretNamespace")
+	}
+
+	accessModes, err := pv.AccessModes.ToKube()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.accessModes")
+	}
+
+	reclaimPolicy, err := pv.ReclaimPolicy.ToKube()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.reclaimPolicy")
+	}
+
+	volumeMode, err := pv.VolumeMode.ToKube()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.volumeMode")
+	}
+
+	kubePV := &v1.PersistentVolume{}
+	kubePV.Name = pv.Name
+	kubePV.Namespace = pv.Namespace
+	kubePV.APIVersion = "v1"
+	kubePV.ClusterName = pv.Cluster
+	kubePV.Kind = "PersistentVolume"
+	kubePV.Labels = pv.Labels
+	kubePV.Annotations = pv.Annotations
+	kubePV.Spec = v1.PersistentVolumeSpec{
+		PersistentVolumeSource:        *source,
+		AccessModes:                   accessModes,
+		ClaimRef:                      pv.ClaimRef,
+		PersistentVolumeReclaimPolicy: reclaimPolicy,
+		StorageClassName:              pv.StorageClass,
+		MountOptions:                  pv.MountOptions,
+		VolumeMode:                    volumeMode,
+	}
+
+	if pv.Capacity != nil {
+		kubePV.Spec.Capacity = v1.ResourceList{
+			v1.ResourceStorage: *pv.Capacity,
+		}
+	}
+
+	if pv.NodeAffinity != nil {
+		kubePV.Spec.NodeAffinity = &v1.VolumeNodeAffinity{
+			Required: pv.NodeAffinity,
+		}
+	}
+
+	return kubePV, nil
+}
+
+// toKubeV1 converts the source through the pod volume of the same type.
+// Volume types that only pods support, e.g. empty_dir, don't have a
+// persistent volume equivalent and are rejected.
+func (s *Source) toKubeV1() (*v1.PersistentVolumeSource, error) {
+	switch {
+	case s.Local != nil:
+		return &v1.PersistentVolumeSource{
+			Local: &v1.LocalVolumeSource{Path: s.Local.Path},
+		}, nil
+	case s.CSI != nil:
+		csi := v1.CSIPersistentVolumeSource(*s.CSI)
+		return &v1.PersistentVolumeSource{CSI: &csi}, nil
+	case s.Volume == nil:
+		return nil, serrors.InvalidInstanceErrorf(s, "empty volume source")
+	}
+
+	kubeVolume, err := s.Volume.ToKube("v1")
+	if err != nil {
+		return nil, err
+	}
+
+	v1Volume, ok := kubeVolume.(*v1.Volume)
+	if !ok {
+		return nil, serrors.InvalidInstanceErrorf(s, "expected a v1 volume, got %T", kubeVolume)
+	}
+
+	kubeSource := &v1.PersistentVolumeSource{}
+	err = converterutils.ConvertKubeObject(&v1Volume.VolumeSource, kubeSource)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(s, "couldn't convert to a persistent volume source: %s", err)
+	}
+
+	if reflect.DeepEqual(*kubeSource, v1.PersistentVolumeSource{}) {
+		return nil, serrors.InvalidInstanceErrorf(s, "volume type isn't supported by persistent volumes")
+	}
+
+	return kubeSource, nil
+}
+
+func (pv *PersistentVolume) toKubeV1SecretNamespace(kubeSource *v1.PersistentVolumeSource) error {
+	if len(pv.SecretNamespace) == 0 {
+		return nil
+	}
+
+	var secretRef *v1.SecretReference
+	switch {
+	case kubeSource.RBD != nil:
+		secretRef = kubeSource.RBD.SecretRef
+	case kubeSource.ISCSI != nil:
+		secretRef = kubeSource.ISCSI.SecretRef
+	case kubeSource.CephFS != nil:
+		secretRef = kubeSource.CephFS.SecretRef
+	case kubeSource.FlexVolume != nil:
+		secretRef = kubeSource.FlexVolume.SecretRef
+	case kubeSource.ScaleIO != nil:
+		secretRef = kubeSource.ScaleIO.SecretRef
+	case kubeSource.AzureFile != nil:
+		kubeSource.AzureFile.SecretNamespace = &pv.SecretNamespace
+		return nil
+	case kubeSource.StorageOS != nil:
+		if kubeSource.StorageOS.SecretRef != nil {
+			kubeSource.StorageOS.SecretRef.Namespace = pv.SecretNamespace
+			return nil
+		}
+	}
+
+	if secretRef == nil {
+		return serrors.InvalidValueErrorf(pv.SecretNamespace, "the volume source doesn't reference a secret")
+	}
+
+	secretRef.Namespace = pv.SecretNamespace
+	return nil
+}
+
+// ToKube converts the reclaim policy for a kubernetes persistent volume or
+// storage class
+func (p ReclaimPolicy) ToKube() (v1.PersistentVolumeReclaimPolicy, error) {
+	switch p {
+	case ReclaimPolicyUnset:
+		return "", nil
+	case ReclaimPolicyRetain:
+		return v1.PersistentVolumeReclaimRetain, nil
+	case ReclaimPolicyRecycle:
+		return v1.PersistentVolumeReclaimRecycle, nil
+	case ReclaimPolicyDelete:
+		return v1.PersistentVolumeReclaimDelete, nil
+	default:
+		return "", serrors.InvalidValueErrorf(p, "unrecognized reclaim policy")
+	}
+}
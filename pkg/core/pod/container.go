@@ -0,0 +1,53 @@
+package pod
+
+import (
+	"k8s.io/api/core/v1"
+)
+
+type PullPolicy string
+
+const (
+	PullPolicyUnset        PullPolicy = ""
+	PullPolicyAlways       PullPolicy = "always"
+	PullPolicyNever        PullPolicy = "never"
+	PullPolicyIfNotPresent PullPolicy = "if-not-present"
+)
+
+type TerminationMessagePolicy string
+
+const (
+	TerminationMessagePolicyUnset                 TerminationMessagePolicy = ""
+	TerminationMessagePolicyFile                  TerminationMessagePolicy = "file"
+	TerminationMessagePolicyFallbackToLogsOnError TerminationMessagePolicy = "fallback-to-logs-on-error"
+)
+
+// Container defines a single container in a pod
+type Container struct {
+	Name       string     `json:"name,omitempty"`
+	Image      string     `json:"image,omitempty"`
+	Pull       PullPolicy `json:"pull,omitempty"`
+	Command    []string   `json:"command,omitempty"`
+	Args       []string   `json:"args,omitempty"`
+	WorkingDir string     `json:"workingDir,omitempty"`
+
+	Ports     []Port                   `json:"ports,omitempty"`
+	EnvFrom   []EnvFrom                `json:"envFrom,omitempty"`
+	Env       []Env                    `json:"env,omitempty"`
+	Resources map[string]ResourceRange `json:"resources,omitempty"`
+
+	VolumeMounts  []v1.VolumeMount  `json:"volumeMounts,omitempty"`
+	VolumeDevices []v1.VolumeDevice `json:"volumeDevices,omitempty"`
+
+	LivenessProbe  *v1.Probe     `json:"livenessProbe,omitempty"`
+	ReadinessProbe *v1.Probe     `json:"readinessProbe,omitempty"`
+	Lifecycle      *v1.Lifecycle `json:"lifecycle,omitempty"`
+
+	TerminationMessagePath   string                   `json:"terminationMessagePath,omitempty"`
+	TerminationMessagePolicy TerminationMessagePolicy `json:"terminationMessagePolicy,omitempty"`
+
+	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
+
+	Stdin     bool `json:"stdin,omitempty"`
+	StdinOnce bool `json:"stdinOnce,omitempty"`
+	TTY       bool `json:"tty,omitempty"`
+}
@@ -0,0 +1,145 @@
+package pod
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestContainerRoundTrip(t *testing.T) {
+	kubeContainer := &v1.Container{
+		Name:  "app",
+		Image: "nginx:1.15",
+		Ports: []v1.ContainerPort{
+			{ContainerPort: 80},
+			{Name: "https", HostPort: 8443, ContainerPort: 443, Protocol: v1.ProtocolTCP},
+			{HostIP: "127.0.0.1", HostPort: 53, ContainerPort: 53, Protocol: v1.ProtocolUDP},
+			{HostIP: "::1", HostPort: 9090, ContainerPort: 9090},
+		},
+		EnvFrom: []v1.EnvFromSource{
+			{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "settings"}}},
+			{Prefix: "DB_", SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "db"}, Optional: boolPtr(true)}},
+		},
+		Env: []v1.EnvVar{
+			{Name: "MODE", Value: "a=b"},
+			{Name: "EMPTY"},
+			{Name: "LEVEL", ValueFrom: &v1.EnvVarSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "settings"},
+					Key:                  "level",
+					Optional:             boolPtr(false),
+				},
+			}},
+			{Name: "PASSWORD", ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "db"},
+					Key:                  "password",
+				},
+			}},
+			{Name: "POD", ValueFrom: &v1.EnvVarSource{
+				FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"},
+			}},
+			{Name: "CPU", ValueFrom: &v1.EnvVarSource{
+				ResourceFieldRef: &v1.ResourceFieldSelector{Resource: "limits.cpu", Divisor: resource.MustParse("1m")},
+			}},
+		},
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("100m"),
+				v1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			Limits: v1.ResourceList{
+				v1.ResourceCPU:             resource.MustParse("500m"),
+				v1.ResourceMemory:          resource.MustParse("128Mi"),
+				v1.ResourceName("foo/gpu"): resource.MustParse("1"),
+			},
+		},
+	}
+
+	container, err := fromKubeContainerV1(kubeContainer)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(container)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	container = &Container{}
+	if err := json.Unmarshal(data, container); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := container.toKubeV1()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeContainer) {
+		t.Errorf("round trip changed the container\nexpected %#v\ngot      %#v", kubeContainer, obj)
+	}
+}
+
+func TestContainerShorthand(t *testing.T) {
+	testcases := []struct {
+		description string
+		data        string
+		pass        bool
+	}{
+		{
+			description: "ports",
+			data:        `{"ports": ["80", "8080:80/tcp", "10.0.0.1:53:53/udp", "[::1]:80:80", {"http": "8080"}]}`,
+			pass:        true,
+		},
+		{
+			description: "unknown protocol",
+			data:        `{"ports": ["80/icmp"]}`,
+			pass:        false,
+		},
+		{
+			description: "port out of range",
+			data:        `{"ports": ["80000"]}`,
+			pass:        false,
+		},
+		{
+			description: "env",
+			data:        `{"env": ["A=1", {"B": "secret:db:password:optional"}, {"C": "resource:requests.memory:app:1Mi"}]}`,
+			pass:        true,
+		},
+		{
+			description: "env without value",
+			data:        `{"env": ["A"]}`,
+			pass:        false,
+		},
+		{
+			description: "unknown env source",
+			data:        `{"env": [{"A": "vault:db:password"}]}`,
+			pass:        false,
+		},
+		{
+			description: "resources",
+			data:        `{"resources": {"cpu": "100m..500m", "memory": "..1Gi", "foo/gpu": 1}}`,
+			pass:        true,
+		},
+		{
+			description: "empty resource range",
+			data:        `{"resources": {"cpu": ".."}}`,
+			pass:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		container := Container{}
+		err := json.Unmarshal([]byte(tc.data), &container)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.description, err)
+		}
+	}
+}
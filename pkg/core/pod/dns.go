@@ -0,0 +1,47 @@
+package pod
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// DNSConfig holds the DNS settings written to the pod's resolv.conf in
+// addition to the ones generated from the DNS policy
+type DNSConfig struct {
+	Nameservers []string    `json:"nameservers,omitempty"`
+	Searches    []string    `json:"searches,omitempty"`
+	Options     []DNSOption `json:"options,omitempty"`
+}
+
+// DNSOption is a resolver option written as "name" or "name:value",
+// e.g. "ndots:2"
+type DNSOption struct {
+	Name  string
+	Value *string
+}
+
+func (o *DNSOption) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form name[:value]")
+	}
+
+	segments := strings.SplitN(str, ":", 2)
+	o.Name = segments[0]
+	if len(segments) > 1 {
+		o.Value = &segments[1]
+	}
+
+	return nil
+}
+
+func (o DNSOption) MarshalJSON() ([]byte, error) {
+	if o.Value == nil {
+		return json.Marshal(o.Name)
+	}
+
+	return json.Marshal(o.Name + ":" + *o.Value)
+}
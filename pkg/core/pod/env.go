@@ -0,0 +1,260 @@
+package pod
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/koki/json"
+	"github.com/koki/json/jsonutil"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type EnvSourceType string
+
+const (
+	EnvSourceConfigMap EnvSourceType = "config-map"
+	EnvSourceSecret    EnvSourceType = "secret"
+	EnvSourceField     EnvSourceType = "field"
+	EnvSourceResource  EnvSourceType = "resource"
+)
+
+const (
+	SelectorSegmentOptional = "optional"
+	SelectorSegmentRequired = "required"
+)
+
+// Env is an environment variable of a container.  Literal values are
+// written as "NAME=value".  Values read from elsewhere are written as a
+// dictionary from the variable name to its source, see EnvSource.
+type Env struct {
+	Name  string
+	Value string
+	From  *EnvSource
+}
+
+// EnvSource is where the value of an environment variable is read from:
+//
+//	config-map:name:key[:optional|:required]
+//	secret:name:key[:optional|:required]
+//	field:path[:apiVersion]
+//	resource:resource[:container[:divisor]]
+type EnvSource struct {
+	Type EnvSourceType
+	// Name is the config map or secret, or the container for resources
+	Name string
+	// Key is the config map or secret key, the field path or the resource
+	Key        string
+	Optional   *bool
+	APIVersion string
+	Divisor    *resource.Quantity
+}
+
+// EnvFrom imports every key of a config map or secret as environment
+// variables.  It is written as "[prefix=]config-map:name[:optional]" or
+// "[prefix=]secret:name[:optional]".
+type EnvFrom struct {
+	Prefix   string
+	Type     EnvSourceType
+	Name     string
+	Optional *bool
+}
+
+func (e *Env) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		fields := strings.SplitN(str, "=", 2)
+		if len(fields) != 2 {
+			return serrors.InvalidValueErrorf(str, "expected NAME=value")
+		}
+		e.Name = fields[0]
+		e.Value = fields[1]
+		return nil
+	}
+
+	obj := map[string]interface{}{}
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected either NAME=value or a dictionary from NAME to its source")
+	}
+
+	name, val, err := jsonutil.GetOnlyMapEntry(obj)
+	if err != nil {
+		return err
+	}
+	source, ok := val.(string)
+	if !ok {
+		return serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(val, "expected a string for the env source"), "$.%s", name)
+	}
+
+	e.Name = name
+	e.From = &EnvSource{}
+	err = e.From.Unmarshal(source)
+	if err != nil {
+		return serrors.ContextualizeErrorf(err, "$.%s", name)
+	}
+
+	return nil
+}
+
+func (e Env) MarshalJSON() ([]byte, error) {
+	if e.From == nil {
+		return json.Marshal(fmt.Sprintf("%s=%s", e.Name, e.Value))
+	}
+
+	source, err := e.From.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]string{e.Name: source})
+}
+
+func (s *EnvSource) Unmarshal(str string) error {
+	segments := strings.Split(str, ":")
+	if len(segments) < 2 {
+		return serrors.InvalidValueErrorf(str, "expected an env source of the form type:selector")
+	}
+
+	s.Type = EnvSourceType(segments[0])
+	selector := segments[1:]
+	switch s.Type {
+	case EnvSourceConfigMap, EnvSourceSecret:
+		if len(selector) < 2 || len(selector) > 3 {
+			return serrors.InvalidValueErrorf(str, "expected %s:name:key[:%s]", s.Type, SelectorSegmentOptional)
+		}
+		s.Name = selector[0]
+		s.Key = selector[1]
+		if len(selector) > 2 {
+			optional, err := unmarshalOptional(selector[2])
+			if err != nil {
+				return err
+			}
+			s.Optional = optional
+		}
+	case EnvSourceField:
+		if len(selector) > 2 {
+			return serrors.InvalidValueErrorf(str, "expected %s:path[:apiVersion]", s.Type)
+		}
+		s.Key = selector[0]
+		if len(selector) > 1 {
+			s.APIVersion = selector[1]
+		}
+	case EnvSourceResource:
+		if len(selector) > 3 {
+			return serrors.InvalidValueErrorf(str, "expected %s:resource[:container[:divisor]]", s.Type)
+		}
+		s.Key = selector[0]
+		if len(selector) > 1 {
+			s.Name = selector[1]
+		}
+		if len(selector) > 2 {
+			divisor, err := resource.ParseQuantity(selector[2])
+			if err != nil {
+				return serrors.InvalidValueErrorf(selector[2], "invalid divisor: %s", err)
+			}
+			s.Divisor = &divisor
+		}
+	default:
+		return serrors.InvalidValueErrorf(s.Type, "unsupported env source, expected one of %s, %s, %s or %s", EnvSourceConfigMap, EnvSourceSecret, EnvSourceField, EnvSourceResource)
+	}
+
+	return nil
+}
+
+func (s EnvSource) Marshal() (string, error) {
+	segments := []string{string(s.Type)}
+	switch s.Type {
+	case EnvSourceConfigMap, EnvSourceSecret:
+		segments = append(segments, s.Name, s.Key)
+		if s.Optional != nil {
+			segments = append(segments, marshalOptional(*s.Optional))
+		}
+	case EnvSourceField:
+		segments = append(segments, s.Key)
+		if len(s.APIVersion) > 0 {
+			segments = append(segments, s.APIVersion)
+		}
+	case EnvSourceResource:
+		segments = append(segments, s.Key)
+		if s.Divisor != nil {
+			segments = append(segments, s.Name, s.Divisor.String())
+		} else if len(s.Name) > 0 {
+			segments = append(segments, s.Name)
+		}
+	default:
+		return "", serrors.InvalidInstanceErrorf(s, "unsupported env source")
+	}
+
+	return strings.Join(segments, ":"), nil
+}
+
+func (e *EnvFrom) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form [prefix=]type:name[:optional]")
+	}
+
+	source := str
+	if fields := strings.SplitN(str, "=", 2); len(fields) == 2 {
+		e.Prefix = fields[0]
+		source = fields[1]
+	}
+
+	segments := strings.Split(source, ":")
+	if len(segments) < 2 || len(segments) > 3 {
+		return serrors.InvalidValueErrorf(str, "expected [prefix=]type:name[:%s]", SelectorSegmentOptional)
+	}
+
+	e.Type = EnvSourceType(segments[0])
+	switch e.Type {
+	case EnvSourceConfigMap, EnvSourceSecret:
+	default:
+		return serrors.InvalidValueErrorf(e.Type, "unsupported env source, expected %s or %s", EnvSourceConfigMap, EnvSourceSecret)
+	}
+
+	e.Name = segments[1]
+	if len(segments) > 2 {
+		optional, err := unmarshalOptional(segments[2])
+		if err != nil {
+			return err
+		}
+		e.Optional = optional
+	}
+
+	return nil
+}
+
+func (e EnvFrom) MarshalJSON() ([]byte, error) {
+	str := fmt.Sprintf("%s:%s", e.Type, e.Name)
+	if len(e.Prefix) > 0 {
+		str = fmt.Sprintf("%s=%s", e.Prefix, str)
+	}
+	if e.Optional != nil {
+		str = fmt.Sprintf("%s:%s", str, marshalOptional(*e.Optional))
+	}
+
+	return json.Marshal(str)
+}
+
+func unmarshalOptional(segment string) (*bool, error) {
+	switch segment {
+	case SelectorSegmentOptional:
+		optional := true
+		return &optional, nil
+	case SelectorSegmentRequired:
+		optional := false
+		return &optional, nil
+	default:
+		return nil, serrors.InvalidValueErrorf(segment, "expected %s or %s", SelectorSegmentOptional, SelectorSegmentRequired)
+	}
+}
+
+func marshalOptional(optional bool) string {
+	if optional {
+		return SelectorSegmentOptional
+	}
+	return SelectorSegmentRequired
+}
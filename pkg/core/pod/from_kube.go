@@ -0,0 +1,519 @@
+package pod
+
+import (
+	"fmt"
+	"reflect"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+)
+
+// NewPodFromKubePod will create a new Pod object with the data from a
+// provided kubernetes pod object
+func NewPodFromKubePod(obj interface{}) (*Pod, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.Pod{}):
+		o := obj.(v1.Pod)
+		return fromKubePodV1(&o)
+	case reflect.TypeOf(&v1.Pod{}):
+		return fromKubePodV1(obj.(*v1.Pod))
+	default:
+		return nil, fmt.Errorf("unknown Pod version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubePodV1(kubePod *v1.Pod) (*Pod, error) {
+	spec, err := fromKubePodSpecV1(&kubePod.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	return &Pod{
+		Name:        kubePod.Name,
+		Namespace:   kubePod.Namespace,
+		Version:     kubePod.APIVersion,
+		Cluster:     kubePod.ClusterName,
+		Labels:      kubePod.Labels,
+		Annotations: kubePod.Annotations,
+		PodSpec:     *spec,
+	}, nil
+}
+
+// NewPodTemplateFromKubePodTemplateSpec will create a new PodTemplate
+// object with the data from a provided kubernetes pod template spec object
+func NewPodTemplateFromKubePodTemplateSpec(obj interface{}) (*PodTemplate, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.PodTemplateSpec{}):
+		o := obj.(v1.PodTemplateSpec)
+		return fromKubePodTemplateSpecV1(&o)
+	case reflect.TypeOf(&v1.PodTemplateSpec{}):
+		return fromKubePodTemplateSpecV1(obj.(*v1.PodTemplateSpec))
+	default:
+		return nil, fmt.Errorf("unknown PodTemplateSpec version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubePodTemplateSpecV1(kubeTemplate *v1.PodTemplateSpec) (*PodTemplate, error) {
+	spec, err := fromKubePodSpecV1(&kubeTemplate.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	return &PodTemplate{
+		Name:        kubeTemplate.Name,
+		Labels:      kubeTemplate.Labels,
+		Annotations: kubeTemplate.Annotations,
+		PodSpec:     *spec,
+	}, nil
+}
+
+// NewPodSpecFromKubePodSpec will create a new PodSpec object with the
+// data from a provided kubernetes pod spec object
+func NewPodSpecFromKubePodSpec(obj interface{}) (*PodSpec, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.PodSpec{}):
+		o := obj.(v1.PodSpec)
+		return fromKubePodSpecV1(&o)
+	case reflect.TypeOf(&v1.PodSpec{}):
+		return fromKubePodSpecV1(obj.(*v1.PodSpec))
+	default:
+		return nil, fmt.Errorf("unknown PodSpec version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubePodSpecV1(kubeSpec *v1.PodSpec) (*PodSpec, error) {
+	spec := &PodSpec{
+		TerminationGracePeriod: kubeSpec.TerminationGracePeriodSeconds,
+		ActiveDeadline:         kubeSpec.ActiveDeadlineSeconds,
+		DNSConfig:              fromKubePodDNSConfigV1(kubeSpec.DNSConfig),
+		Hostname:               kubeSpec.Hostname,
+		Subdomain:              kubeSpec.Subdomain,
+		HostAliases:            kubeSpec.HostAliases,
+		HostNetwork:            kubeSpec.HostNetwork,
+		HostPID:                kubeSpec.HostPID,
+		HostIPC:                kubeSpec.HostIPC,
+		ShareProcessNamespace:  kubeSpec.ShareProcessNamespace,
+		ServiceAccount:         kubeSpec.ServiceAccountName,
+		AutomountSAToken:       kubeSpec.AutomountServiceAccountToken,
+		SecurityContext:        fromKubePodSecurityContextV1(kubeSpec.SecurityContext),
+		NodeName:               kubeSpec.NodeName,
+		NodeSelector:           kubeSpec.NodeSelector,
+		Affinity:               kubeSpec.Affinity,
+		SchedulerName:          kubeSpec.SchedulerName,
+		PriorityClassName:      kubeSpec.PriorityClassName,
+		Priority:               kubeSpec.Priority,
+	}
+
+	// serviceAccount is a deprecated alias of serviceAccountName
+	if len(spec.ServiceAccount) == 0 {
+		spec.ServiceAccount = kubeSpec.DeprecatedServiceAccount
+	}
+
+	names := map[string]bool{}
+	for i := range kubeSpec.Volumes {
+		vol, err := NewVolumeFromKubeVolume(&kubeSpec.Volumes[i])
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.volumes.%d", i)
+		}
+		if names[vol.Name] {
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(vol.Name, "duplicate volume name"), "$.volumes.%d", i)
+		}
+		names[vol.Name] = true
+		spec.Volumes = append(spec.Volumes, *vol)
+	}
+
+	var err error
+	spec.InitContainers, err = fromKubeContainersV1(kubeSpec.InitContainers)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.initContainers")
+	}
+	spec.Containers, err = fromKubeContainersV1(kubeSpec.Containers)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.containers")
+	}
+
+	spec.RestartPolicy, err = fromKubeRestartPolicyV1(kubeSpec.RestartPolicy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.restartPolicy")
+	}
+	spec.DNSPolicy, err = fromKubeDNSPolicyV1(kubeSpec.DNSPolicy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.dnsPolicy")
+	}
+
+	for _, secret := range kubeSpec.ImagePullSecrets {
+		spec.ImagePullSecrets = append(spec.ImagePullSecrets, secret.Name)
+	}
+
+	for i := range kubeSpec.Tolerations {
+		toleration, err := fromKubeTolerationV1(&kubeSpec.Tolerations[i])
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.tolerations.%d", i)
+		}
+		spec.Tolerations = append(spec.Tolerations, *toleration)
+	}
+
+	return spec, nil
+}
+
+func fromKubeRestartPolicyV1(policy v1.RestartPolicy) (RestartPolicy, error) {
+	switch policy {
+	case "":
+		return RestartPolicyUnset, nil
+	case v1.RestartPolicyAlways:
+		return RestartPolicyAlways, nil
+	case v1.RestartPolicyOnFailure:
+		return RestartPolicyOnFailure, nil
+	case v1.RestartPolicyNever:
+		return RestartPolicyNever, nil
+	default:
+		return RestartPolicyUnset, serrors.InvalidValueErrorf(policy, "unrecognized restart policy")
+	}
+}
+
+func fromKubeDNSPolicyV1(policy v1.DNSPolicy) (DNSPolicy, error) {
+	switch policy {
+	case "":
+		return DNSPolicyUnset, nil
+	case v1.DNSClusterFirst:
+		return DNSPolicyClusterFirst, nil
+	case v1.DNSClusterFirstWithHostNet:
+		return DNSPolicyClusterFirstWithHostNet, nil
+	case v1.DNSDefault:
+		return DNSPolicyDefault, nil
+	case v1.DNSNone:
+		return DNSPolicyNone, nil
+	default:
+		return DNSPolicyUnset, serrors.InvalidValueErrorf(policy, "unrecognized dns policy")
+	}
+}
+
+func fromKubePodDNSConfigV1(config *v1.PodDNSConfig) *DNSConfig {
+	if config == nil {
+		return nil
+	}
+
+	dnsConfig := &DNSConfig{
+		Nameservers: config.Nameservers,
+		Searches:    config.Searches,
+	}
+	for _, opt := range config.Options {
+		dnsConfig.Options = append(dnsConfig.Options, DNSOption{
+			Name:  opt.Name,
+			Value: opt.Value,
+		})
+	}
+
+	return dnsConfig
+}
+
+func fromKubeContainersV1(kubeContainers []v1.Container) ([]Container, error) {
+	var containers []Container
+	for i := range kubeContainers {
+		container, err := fromKubeContainerV1(&kubeContainers[i])
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		containers = append(containers, *container)
+	}
+
+	return containers, nil
+}
+
+func fromKubeContainerV1(kubeContainer *v1.Container) (*Container, error) {
+	container := &Container{
+		Name:                   kubeContainer.Name,
+		Image:                  kubeContainer.Image,
+		Command:                kubeContainer.Command,
+		Args:                   kubeContainer.Args,
+		WorkingDir:             kubeContainer.WorkingDir,
+		VolumeMounts:           kubeContainer.VolumeMounts,
+		VolumeDevices:          kubeContainer.VolumeDevices,
+		LivenessProbe:          kubeContainer.LivenessProbe,
+		ReadinessProbe:         kubeContainer.ReadinessProbe,
+		Lifecycle:              kubeContainer.Lifecycle,
+		TerminationMessagePath: kubeContainer.TerminationMessagePath,
+		SecurityContext:        fromKubeSecurityContextV1(kubeContainer.SecurityContext),
+		Stdin:                  kubeContainer.Stdin,
+		StdinOnce:              kubeContainer.StdinOnce,
+		TTY:                    kubeContainer.TTY,
+	}
+
+	var err error
+	container.Ports, err = fromKubeContainerPortsV1(kubeContainer.Ports)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.ports")
+	}
+	container.Env, err = fromKubeEnvVarsV1(kubeContainer.Env)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.env")
+	}
+	container.EnvFrom, err = fromKubeEnvFromSourcesV1(kubeContainer.EnvFrom)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.envFrom")
+	}
+	container.Resources = fromKubeResourceRequirementsV1(&kubeContainer.Resources)
+
+	container.Pull, err = fromKubePullPolicyV1(kubeContainer.ImagePullPolicy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.imagePullPolicy")
+	}
+	container.TerminationMessagePolicy, err = fromKubeTerminationMessagePolicyV1(kubeContainer.TerminationMessagePolicy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.terminationMessagePolicy")
+	}
+
+	return container, nil
+}
+
+func fromKubeContainerPortsV1(kubePorts []v1.ContainerPort) ([]Port, error) {
+	var ports []Port
+	for i, kubePort := range kubePorts {
+		port := Port{
+			Name:          kubePort.Name,
+			HostIP:        kubePort.HostIP,
+			HostPort:      kubePort.HostPort,
+			ContainerPort: kubePort.ContainerPort,
+		}
+
+		switch kubePort.Protocol {
+		case "":
+			port.Protocol = ProtocolUnset
+		case v1.ProtocolTCP:
+			port.Protocol = ProtocolTCP
+		case v1.ProtocolUDP:
+			port.Protocol = ProtocolUDP
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(kubePort.Protocol, "unrecognized protocol"), "$.%d", i)
+		}
+
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
+
+func fromKubeEnvVarsV1(kubeEnv []v1.EnvVar) ([]Env, error) {
+	var env []Env
+	for i, kubeVar := range kubeEnv {
+		e := Env{
+			Name:  kubeVar.Name,
+			Value: kubeVar.Value,
+		}
+
+		if kubeVar.ValueFrom != nil {
+			if len(kubeVar.Value) > 0 {
+				return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(kubeVar, "env var can't have both a value and a source"), "$.%d", i)
+			}
+
+			from, err := fromKubeEnvVarSourceV1(kubeVar.ValueFrom)
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+			}
+			e.From = from
+		}
+
+		env = append(env, e)
+	}
+
+	return env, nil
+}
+
+func fromKubeEnvVarSourceV1(kubeSource *v1.EnvVarSource) (*EnvSource, error) {
+	sources := []*EnvSource{}
+	if ref := kubeSource.ConfigMapKeyRef; ref != nil {
+		sources = append(sources, &EnvSource{
+			Type:     EnvSourceConfigMap,
+			Name:     ref.Name,
+			Key:      ref.Key,
+			Optional: ref.Optional,
+		})
+	}
+	if ref := kubeSource.SecretKeyRef; ref != nil {
+		sources = append(sources, &EnvSource{
+			Type:     EnvSourceSecret,
+			Name:     ref.Name,
+			Key:      ref.Key,
+			Optional: ref.Optional,
+		})
+	}
+	if ref := kubeSource.FieldRef; ref != nil {
+		sources = append(sources, &EnvSource{
+			Type:       EnvSourceField,
+			Key:        ref.FieldPath,
+			APIVersion: ref.APIVersion,
+		})
+	}
+	if ref := kubeSource.ResourceFieldRef; ref != nil {
+		source := &EnvSource{
+			Type: EnvSourceResource,
+			Name: ref.ContainerName,
+			Key:  ref.Resource,
+		}
+		if !ref.Divisor.IsZero() {
+			divisor := ref.Divisor
+			source.Divisor = &divisor
+		}
+		sources = append(sources, source)
+	}
+
+	if len(sources) != 1 {
+		return nil, serrors.InvalidInstanceErrorf(kubeSource, "expected exactly one env var source")
+	}
+
+	return sources[0], nil
+}
+
+func fromKubeEnvFromSourcesV1(kubeSources []v1.EnvFromSource) ([]EnvFrom, error) {
+	var envFrom []EnvFrom
+	for i, kubeSource := range kubeSources {
+		e := EnvFrom{
+			Prefix: kubeSource.Prefix,
+		}
+
+		switch {
+		case kubeSource.ConfigMapRef != nil && kubeSource.SecretRef == nil:
+			e.Type = EnvSourceConfigMap
+			e.Name = kubeSource.ConfigMapRef.Name
+			e.Optional = kubeSource.ConfigMapRef.Optional
+		case kubeSource.SecretRef != nil && kubeSource.ConfigMapRef == nil:
+			e.Type = EnvSourceSecret
+			e.Name = kubeSource.SecretRef.Name
+			e.Optional = kubeSource.SecretRef.Optional
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(kubeSource, "expected exactly one of configMapRef or secretRef"), "$.%d", i)
+		}
+
+		envFrom = append(envFrom, e)
+	}
+
+	return envFrom, nil
+}
+
+func fromKubeResourceRequirementsV1(kubeResources *v1.ResourceRequirements) map[string]ResourceRange {
+	if len(kubeResources.Requests) == 0 && len(kubeResources.Limits) == 0 {
+		return nil
+	}
+
+	resources := map[string]ResourceRange{}
+	for name, quantity := range kubeResources.Requests {
+		request := quantity
+		resources[string(name)] = ResourceRange{Request: &request}
+	}
+	for name, quantity := range kubeResources.Limits {
+		limit := quantity
+		r := resources[string(name)]
+		r.Limit = &limit
+		resources[string(name)] = r
+	}
+
+	return resources
+}
+
+func fromKubePullPolicyV1(policy v1.PullPolicy) (PullPolicy, error) {
+	switch policy {
+	case "":
+		return PullPolicyUnset, nil
+	case v1.PullAlways:
+		return PullPolicyAlways, nil
+	case v1.PullNever:
+		return PullPolicyNever, nil
+	case v1.PullIfNotPresent:
+		return PullPolicyIfNotPresent, nil
+	default:
+		return PullPolicyUnset, serrors.InvalidValueErrorf(policy, "unrecognized image pull policy")
+	}
+}
+
+func fromKubeTerminationMessagePolicyV1(policy v1.TerminationMessagePolicy) (TerminationMessagePolicy, error) {
+	switch policy {
+	case "":
+		return TerminationMessagePolicyUnset, nil
+	case v1.TerminationMessageReadFile:
+		return TerminationMessagePolicyFile, nil
+	case v1.TerminationMessageFallbackToLogsOnError:
+		return TerminationMessagePolicyFallbackToLogsOnError, nil
+	default:
+		return TerminationMessagePolicyUnset, serrors.InvalidValueErrorf(policy, "unrecognized termination message policy")
+	}
+}
+
+func fromKubeSELinuxOptionsV1(opts *v1.SELinuxOptions) *SELinux {
+	if opts == nil {
+		return nil
+	}
+
+	return &SELinux{
+		User:  opts.User,
+		Role:  opts.Role,
+		Type:  opts.Type,
+		Level: opts.Level,
+	}
+}
+
+func fromKubePodSecurityContextV1(sc *v1.PodSecurityContext) *PodSecurityContext {
+	if sc == nil {
+		return nil
+	}
+
+	return &PodSecurityContext{
+		SELinux:            fromKubeSELinuxOptionsV1(sc.SELinuxOptions),
+		RunAsUser:          sc.RunAsUser,
+		RunAsGroup:         sc.RunAsGroup,
+		RunAsNonRoot:       sc.RunAsNonRoot,
+		SupplementalGroups: sc.SupplementalGroups,
+		FSGroup:            sc.FSGroup,
+	}
+}
+
+func fromKubeSecurityContextV1(sc *v1.SecurityContext) *SecurityContext {
+	if sc == nil {
+		return nil
+	}
+
+	return &SecurityContext{
+		Capabilities:             sc.Capabilities,
+		Privileged:               sc.Privileged,
+		SELinux:                  fromKubeSELinuxOptionsV1(sc.SELinuxOptions),
+		RunAsUser:                sc.RunAsUser,
+		RunAsGroup:               sc.RunAsGroup,
+		RunAsNonRoot:             sc.RunAsNonRoot,
+		ReadOnlyRootFilesystem:   sc.ReadOnlyRootFilesystem,
+		AllowPrivilegeEscalation: sc.AllowPrivilegeEscalation,
+	}
+}
+
+func fromKubeTolerationV1(kubeToleration *v1.Toleration) (*Toleration, error) {
+	t := &Toleration{
+		Key:               kubeToleration.Key,
+		Value:             kubeToleration.Value,
+		TolerationSeconds: kubeToleration.TolerationSeconds,
+	}
+
+	switch kubeToleration.Operator {
+	case v1.TolerationOpExists:
+		t.Exists = true
+		if len(t.Value) > 0 {
+			return nil, serrors.InvalidInstanceErrorf(kubeToleration, "toleration with operator %s can't have a value", v1.TolerationOpExists)
+		}
+	case v1.TolerationOpEqual, "":
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeToleration.Operator, "unsupported toleration operator")
+	}
+
+	switch kubeToleration.Effect {
+	case "":
+		t.Effect = TaintEffectAll
+	case v1.TaintEffectNoSchedule:
+		t.Effect = TaintEffectNoSchedule
+	case v1.TaintEffectPreferNoSchedule:
+		t.Effect = TaintEffectPreferNoSchedule
+	case v1.TaintEffectNoExecute:
+		t.Effect = TaintEffectNoExecute
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeToleration.Effect, "unsupported taint effect")
+	}
+
+	return t, nil
+}
@@ -0,0 +1,62 @@
+package pod
+
+import (
+	"k8s.io/api/core/v1"
+)
+
+type RestartPolicy string
+
+const (
+	RestartPolicyUnset     RestartPolicy = ""
+	RestartPolicyAlways    RestartPolicy = "always"
+	RestartPolicyOnFailure RestartPolicy = "on-failure"
+	RestartPolicyNever     RestartPolicy = "never"
+)
+
+type DNSPolicy string
+
+const (
+	DNSPolicyUnset                   DNSPolicy = ""
+	DNSPolicyClusterFirst            DNSPolicy = "cluster-first"
+	DNSPolicyClusterFirstWithHostNet DNSPolicy = "cluster-first-with-host-net"
+	DNSPolicyDefault                 DNSPolicy = "default"
+	DNSPolicyNone                    DNSPolicy = "none"
+)
+
+// PodSpec defines the contents of a pod.  It is shared by every resource
+// that runs pods.
+type PodSpec struct {
+	Volumes        Volumes     `json:"volumes,omitempty"`
+	InitContainers []Container `json:"initContainers,omitempty"`
+	Containers     []Container `json:"containers,omitempty"`
+
+	RestartPolicy          RestartPolicy `json:"restartPolicy,omitempty"`
+	TerminationGracePeriod *int64        `json:"terminationGracePeriod,omitempty"`
+	ActiveDeadline         *int64        `json:"activeDeadline,omitempty"`
+
+	DNSPolicy DNSPolicy  `json:"dnsPolicy,omitempty"`
+	DNSConfig *DNSConfig `json:"dnsConfig,omitempty"`
+	Hostname  string     `json:"hostname,omitempty"`
+	Subdomain string     `json:"subdomain,omitempty"`
+
+	HostAliases []v1.HostAlias `json:"hostAliases,omitempty"`
+	HostNetwork bool           `json:"hostNetwork,omitempty"`
+	HostPID     bool           `json:"hostPID,omitempty"`
+	HostIPC     bool           `json:"hostIPC,omitempty"`
+
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty"`
+
+	ServiceAccount   string   `json:"serviceAccount,omitempty"`
+	AutomountSAToken *bool    `json:"automountServiceAccountToken,omitempty"`
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	SecurityContext *PodSecurityContext `json:"securityContext,omitempty"`
+
+	NodeName          string            `json:"nodeName,omitempty"`
+	NodeSelector      map[string]string `json:"nodeSelector,omitempty"`
+	Affinity          *v1.Affinity      `json:"affinity,omitempty"`
+	Tolerations       []Toleration      `json:"tolerations,omitempty"`
+	SchedulerName     string            `json:"schedulerName,omitempty"`
+	PriorityClassName string            `json:"priorityClassName,omitempty"`
+	Priority          *int32            `json:"priority,omitempty"`
+}
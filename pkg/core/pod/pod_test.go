@@ -0,0 +1,188 @@
+package pod
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func testKubePod() *v1.Pod {
+	ndots := "2"
+	return &v1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "testPod",
+			Namespace:   "testNS",
+			ClusterName: "testCluster",
+			Labels:      map[string]string{"app": "test"},
+			Annotations: map[string]string{"ann1": "test1"},
+		},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				// not sorted by name, to check that the order is kept
+				{
+					Name: "data",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"},
+					},
+				},
+				{
+					Name: "cache",
+					VolumeSource: v1.VolumeSource{
+						EmptyDir: &v1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+			InitContainers: []v1.Container{
+				{
+					Name:    "init",
+					Image:   "busybox",
+					Command: []string{"sh", "-c", "true"},
+				},
+			},
+			Containers: []v1.Container{
+				{
+					Name:            "app",
+					Image:           "nginx:1.15",
+					ImagePullPolicy: v1.PullIfNotPresent,
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+					},
+					TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+					SecurityContext: &v1.SecurityContext{
+						SELinuxOptions: &v1.SELinuxOptions{Type: "spc_t", Level: "s0:c1,c2"},
+					},
+				},
+			},
+			RestartPolicy:                 v1.RestartPolicyOnFailure,
+			TerminationGracePeriodSeconds: int64Ptr(30),
+			DNSPolicy:                     v1.DNSClusterFirstWithHostNet,
+			DNSConfig: &v1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Options: []v1.PodDNSConfigOption{
+					{Name: "ndots", Value: &ndots},
+					{Name: "edns0"},
+				},
+			},
+			NodeSelector:       map[string]string{"disk": "ssd"},
+			ServiceAccountName: "builder",
+			ImagePullSecrets:   []v1.LocalObjectReference{{Name: "registry"}},
+			SecurityContext: &v1.PodSecurityContext{
+				RunAsUser: int64Ptr(1000),
+				FSGroup:   int64Ptr(2000),
+			},
+			Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+				{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: int64Ptr(300)},
+				{Operator: v1.TolerationOpExists},
+			},
+		},
+	}
+}
+
+func TestNewPodFromKubePod(t *testing.T) {
+	testcases := []struct {
+		description string
+		obj         interface{}
+		pass        bool
+	}{
+		{
+			description: "v1 pod object",
+			obj:         *testKubePod(),
+			pass:        true,
+		},
+		{
+			description: "v1 pod pointer",
+			obj:         testKubePod(),
+			pass:        true,
+		},
+		{
+			description: "unknown object",
+			obj:         &v1.ConfigMap{},
+			pass:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := NewPodFromKubePod(tc.obj)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.description, err)
+		}
+	}
+}
+
+func TestPodRoundTrip(t *testing.T) {
+	kubePod := testKubePod()
+
+	pod, err := NewPodFromKubePod(kubePod)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	// the mantle document is marshalled and read back to exercise the
+	// shorthand forms
+	data, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	pod = &Pod{}
+	if err := json.Unmarshal(data, pod); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := pod.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubePod) {
+		t.Errorf("round trip changed the pod\nexpected %#v\ngot      %#v", kubePod, obj)
+	}
+}
+
+func TestTolerationShorthand(t *testing.T) {
+	testcases := []struct {
+		str  string
+		pass bool
+	}{
+		{str: "dedicated=gpu:no-schedule", pass: true},
+		{str: "dedicated=", pass: true},
+		{str: "dedicated:prefer-no-schedule", pass: true},
+		{str: "*", pass: true},
+		{str: "*:no-execute:300", pass: true},
+		{str: "dedicated:NoSchedule", pass: false},
+		{str: "dedicated:no-execute:soon", pass: false},
+		{str: "a:no-execute:1:2", pass: false},
+	}
+
+	for _, tc := range testcases {
+		toleration := Toleration{}
+		err := json.Unmarshal([]byte(`"`+tc.str+`"`), &toleration)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		data, err := json.Marshal(toleration)
+		if err != nil {
+			t.Errorf("%s: marshal failed: %v", tc.str, err)
+		}
+		if string(data) != `"`+tc.str+`"` {
+			t.Errorf("%s: round trip produced %s", tc.str, data)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package pod
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mantle/internal/marshal"
+
+	"github.com/koki/json"
+	"github.com/koki/json/jsonutil"
+	serrors "github.com/koki/structurederrors"
+)
+
+type Protocol string
+
+const (
+	ProtocolUnset Protocol = ""
+	ProtocolTCP   Protocol = "tcp"
+	ProtocolUDP   Protocol = "udp"
+)
+
+// Port is a port exposed by a container.  It is written as
+// "[[hostIP:]hostPort:]containerPort[/protocol]", e.g. "8080:80/tcp".
+// IPv6 host addresses are enclosed in brackets.  Named ports are written
+// as a dictionary from the name to the port, e.g. {http: "8080:80"}.
+type Port struct {
+	Name          string
+	HostIP        string
+	HostPort      int32
+	ContainerPort int32
+	Protocol      Protocol
+}
+
+var portRegexp = regexp.MustCompile(`^(?:(?:(\[[0-9a-fA-F:.]*\]|[0-9.]+):)?([0-9]+):)?([0-9]+)(?:/([a-z]+))?$`)
+
+func (p *Port) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		return p.Unmarshal(str)
+	}
+
+	obj := map[string]interface{}{}
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected either a port string or a dictionary from name to port string")
+	}
+
+	name, val, err := jsonutil.GetOnlyMapEntry(obj)
+	if err != nil {
+		return err
+	}
+	str, ok := val.(string)
+	if !ok {
+		return serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(val, "expected a port string"), "$.%s", name)
+	}
+
+	p.Name = name
+	err = p.Unmarshal(str)
+	if err != nil {
+		return serrors.ContextualizeErrorf(err, "$.%s", name)
+	}
+
+	return nil
+}
+
+func (p *Port) Unmarshal(str string) error {
+	matches := portRegexp.FindStringSubmatch(str)
+	if len(matches) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected [[hostIP:]hostPort:]containerPort[/protocol]")
+	}
+
+	p.HostIP = strings.TrimSuffix(strings.TrimPrefix(matches[1], "["), "]")
+	if len(matches[2]) > 0 {
+		port, err := marshal.ParsePort(matches[2])
+		if err != nil {
+			return err
+		}
+		p.HostPort = port
+	}
+
+	port, err := marshal.ParsePort(matches[3])
+	if err != nil {
+		return err
+	}
+	p.ContainerPort = port
+
+	switch protocol := Protocol(matches[4]); protocol {
+	case ProtocolUnset, ProtocolTCP, ProtocolUDP:
+		p.Protocol = protocol
+	default:
+		return serrors.InvalidValueErrorf(matches[4], "unsupported protocol, expected %s or %s", ProtocolTCP, ProtocolUDP)
+	}
+
+	return nil
+}
+
+func (p Port) MarshalJSON() ([]byte, error) {
+	if len(p.Name) == 0 {
+		return json.Marshal(p.String())
+	}
+
+	return json.Marshal(map[string]string{p.Name: p.String()})
+}
+
+func (p Port) String() string {
+	str := strconv.Itoa(int(p.ContainerPort))
+	if p.HostPort != 0 || len(p.HostIP) > 0 {
+		str = fmt.Sprintf("%d:%s", p.HostPort, str)
+	}
+	if strings.Contains(p.HostIP, ":") {
+		str = fmt.Sprintf("[%s]:%s", p.HostIP, str)
+	} else if len(p.HostIP) > 0 {
+		str = fmt.Sprintf("%s:%s", p.HostIP, str)
+	}
+	if len(p.Protocol) > 0 {
+		str = fmt.Sprintf("%s/%s", str, p.Protocol)
+	}
+
+	return str
+}
@@ -0,0 +1,30 @@
+package pod
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "pod"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("Pod"),
+		},
+		New: func() registry.Object {
+			return &Pod{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			pod, err := NewPodFromKubePod(obj)
+			if err != nil {
+				return nil, err
+			}
+			return pod, nil
+		},
+	})
+}
@@ -0,0 +1,93 @@
+package pod
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceRange is the amount of a resource a container requests and its
+// limit.  It is written as "request..limit", e.g. "cpu: 100m..500m".
+// Either end can be left out, "100m.." only sets the request and "..500m"
+// only sets the limit.  A single amount sets both.
+type ResourceRange struct {
+	Request *resource.Quantity
+	Limit   *resource.Quantity
+}
+
+const resourceRangeSeparator = ".."
+
+func (r *ResourceRange) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		// Plain numbers, e.g. "cpu: 2", are read as numbers
+		num := json.Number("")
+		err = json.Unmarshal(data, &num)
+		if err != nil {
+			return serrors.InvalidValueErrorf(string(data), "expected a string of the form request..limit")
+		}
+		str = num.String()
+	}
+
+	fields := strings.Split(str, resourceRangeSeparator)
+	switch len(fields) {
+	case 1:
+		quantity, err := parseQuantity(fields[0])
+		if err != nil {
+			return err
+		}
+		r.Request = quantity
+		r.Limit = quantity
+	case 2:
+		if len(fields[0]) > 0 {
+			r.Request, err = parseQuantity(fields[0])
+			if err != nil {
+				return err
+			}
+		}
+		if len(fields[1]) > 0 {
+			r.Limit, err = parseQuantity(fields[1])
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return serrors.InvalidValueErrorf(str, "expected request..limit")
+	}
+
+	if r.Request == nil && r.Limit == nil {
+		return serrors.InvalidValueErrorf(str, "expected a request or a limit")
+	}
+
+	return nil
+}
+
+func (r ResourceRange) MarshalJSON() ([]byte, error) {
+	if r.Request != nil && r.Limit != nil && r.Request.String() == r.Limit.String() {
+		return json.Marshal(r.Request.String())
+	}
+
+	request := ""
+	if r.Request != nil {
+		request = r.Request.String()
+	}
+	limit := ""
+	if r.Limit != nil {
+		limit = r.Limit.String()
+	}
+
+	return json.Marshal(request + resourceRangeSeparator + limit)
+}
+
+func parseQuantity(str string) (*resource.Quantity, error) {
+	quantity, err := resource.ParseQuantity(str)
+	if err != nil {
+		return nil, serrors.InvalidValueErrorf(str, "invalid quantity: %s", err)
+	}
+
+	return &quantity, nil
+}
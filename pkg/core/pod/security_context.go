@@ -0,0 +1,67 @@
+package pod
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+)
+
+// PodSecurityContext holds the security settings shared by every
+// container in a pod
+type PodSecurityContext struct {
+	SELinux            *SELinux `json:"selinux,omitempty"`
+	RunAsUser          *int64   `json:"runAsUser,omitempty"`
+	RunAsGroup         *int64   `json:"runAsGroup,omitempty"`
+	RunAsNonRoot       *bool    `json:"runAsNonRoot,omitempty"`
+	SupplementalGroups []int64  `json:"supplementalGroups,omitempty"`
+	FSGroup            *int64   `json:"fsGroup,omitempty"`
+}
+
+// SecurityContext holds the security settings of a single container.
+// They take precedence over the pod's security context.
+type SecurityContext struct {
+	Capabilities             *v1.Capabilities `json:"capabilities,omitempty"`
+	Privileged               *bool            `json:"privileged,omitempty"`
+	SELinux                  *SELinux         `json:"selinux,omitempty"`
+	RunAsUser                *int64           `json:"runAsUser,omitempty"`
+	RunAsGroup               *int64           `json:"runAsGroup,omitempty"`
+	RunAsNonRoot             *bool            `json:"runAsNonRoot,omitempty"`
+	ReadOnlyRootFilesystem   *bool            `json:"readOnlyRootFilesystem,omitempty"`
+	AllowPrivilegeEscalation *bool            `json:"allowPrivilegeEscalation,omitempty"`
+}
+
+// SELinux is the SELinux context of a pod or container.  It is written
+// as "user:role:type:level", empty segments are left unset.  The level
+// may contain colons itself, e.g. "s0:c123,c456".
+type SELinux struct {
+	User  string
+	Role  string
+	Type  string
+	Level string
+}
+
+func (s *SELinux) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form user:role:type:level")
+	}
+
+	segments := strings.SplitN(str, ":", 4)
+	if len(segments) != 4 {
+		return serrors.InvalidValueErrorf(str, "expected four selinux segments, user:role:type:level")
+	}
+
+	s.User = segments[0]
+	s.Role = segments[1]
+	s.Type = segments[2]
+	s.Level = segments[3]
+	return nil
+}
+
+func (s SELinux) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join([]string{s.User, s.Role, s.Type, s.Level}, ":"))
+}
@@ -0,0 +1,11 @@
+package pod
+
+// PodTemplate describes the pods created by a controller, e.g. a
+// deployment
+type PodTemplate struct {
+	Name        string            `json:"name,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	PodSpec
+}
@@ -0,0 +1,501 @@
+package pod
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes pod object of the api version
+// type defined in the object
+func (p *Pod) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(p.Version) {
+	case "v1":
+		return p.toKubeV1()
+	case "":
+		return p.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for pod: %s", p.Version)
+	}
+}
+
+func (p *Pod) toKubeV1() (*v1.Pod, error) {
+	spec, err := p.PodSpec.toKubeV1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubePod := &v1.Pod{}
+	kubePod.Name = p.Name
+	kubePod.Namespace = p.Namespace
+	kubePod.APIVersion = p.Version
+	kubePod.ClusterName = p.Cluster
+	kubePod.Kind = "Pod"
+	kubePod.Labels = p.Labels
+	kubePod.Annotations = p.Annotations
+	kubePod.Spec = *spec
+
+	return kubePod, nil
+}
+
+// ToKube will return a kubernetes pod template spec object of the
+// provided api version
+func (t *PodTemplate) ToKube(version string) (interface{}, error) {
+	switch strings.ToLower(version) {
+	case "v1":
+		return t.toKubeV1()
+	case "":
+		return t.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for pod template: %s", version)
+	}
+}
+
+func (t *PodTemplate) toKubeV1() (*v1.PodTemplateSpec, error) {
+	spec, err := t.PodSpec.toKubeV1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeTemplate := &v1.PodTemplateSpec{}
+	kubeTemplate.Name = t.Name
+	kubeTemplate.Labels = t.Labels
+	kubeTemplate.Annotations = t.Annotations
+	kubeTemplate.Spec = *spec
+
+	return kubeTemplate, nil
+}
+
+// ToKube will return a kubernetes pod spec object of the provided
+// api version
+func (s *PodSpec) ToKube(version string) (interface{}, error) {
+	switch strings.ToLower(version) {
+	case "v1":
+		return s.toKubeV1()
+	case "":
+		return s.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for pod spec: %s", version)
+	}
+}
+
+func (s *PodSpec) toKubeV1() (*v1.PodSpec, error) {
+	kubeSpec := &v1.PodSpec{
+		TerminationGracePeriodSeconds: s.TerminationGracePeriod,
+		ActiveDeadlineSeconds:         s.ActiveDeadline,
+		DNSConfig:                     s.DNSConfig.toKubeV1(),
+		Hostname:                      s.Hostname,
+		Subdomain:                     s.Subdomain,
+		HostAliases:                   s.HostAliases,
+		HostNetwork:                   s.HostNetwork,
+		HostPID:                       s.HostPID,
+		HostIPC:                       s.HostIPC,
+		ShareProcessNamespace:         s.ShareProcessNamespace,
+		ServiceAccountName:            s.ServiceAccount,
+		AutomountServiceAccountToken:  s.AutomountSAToken,
+		SecurityContext:               s.SecurityContext.toKubeV1(),
+		NodeName:                      s.NodeName,
+		NodeSelector:                  s.NodeSelector,
+		Affinity:                      s.Affinity,
+		SchedulerName:                 s.SchedulerName,
+		PriorityClassName:             s.PriorityClassName,
+		Priority:                      s.Priority,
+	}
+
+	for i := range s.Volumes {
+		vol := &s.Volumes[i]
+		kubeVolume, err := vol.ToKube("v1")
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.volumes.%d", i)
+		}
+		v1Volume, ok := kubeVolume.(*v1.Volume)
+		if !ok {
+			err := serrors.InvalidInstanceErrorf(vol, "expected a v1 volume, got %T", kubeVolume)
+			return nil, serrors.ContextualizeErrorf(err, "$.volumes.%d", i)
+		}
+		kubeSpec.Volumes = append(kubeSpec.Volumes, *v1Volume)
+	}
+
+	var err error
+	kubeSpec.InitContainers, err = toKubeContainersV1(s.InitContainers)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.initContainers")
+	}
+	kubeSpec.Containers, err = toKubeContainersV1(s.Containers)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.containers")
+	}
+
+	kubeSpec.RestartPolicy, err = s.toKubeV1RestartPolicy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.restartPolicy")
+	}
+	kubeSpec.DNSPolicy, err = s.toKubeV1DNSPolicy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.dnsPolicy")
+	}
+
+	for _, secret := range s.ImagePullSecrets {
+		kubeSpec.ImagePullSecrets = append(kubeSpec.ImagePullSecrets, v1.LocalObjectReference{Name: secret})
+	}
+
+	for i := range s.Tolerations {
+		toleration, err := s.Tolerations[i].toKubeV1()
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.tolerations.%d", i)
+		}
+		kubeSpec.Tolerations = append(kubeSpec.Tolerations, *toleration)
+	}
+
+	return kubeSpec, nil
+}
+
+func (s *PodSpec) toKubeV1RestartPolicy() (v1.RestartPolicy, error) {
+	switch s.RestartPolicy {
+	case RestartPolicyUnset:
+		return "", nil
+	case RestartPolicyAlways:
+		return v1.RestartPolicyAlways, nil
+	case RestartPolicyOnFailure:
+		return v1.RestartPolicyOnFailure, nil
+	case RestartPolicyNever:
+		return v1.RestartPolicyNever, nil
+	default:
+		return "", serrors.InvalidValueErrorf(s.RestartPolicy, "unrecognized restart policy")
+	}
+}
+
+func (s *PodSpec) toKubeV1DNSPolicy() (v1.DNSPolicy, error) {
+	switch s.DNSPolicy {
+	case DNSPolicyUnset:
+		return "", nil
+	case DNSPolicyClusterFirst:
+		return v1.DNSClusterFirst, nil
+	case DNSPolicyClusterFirstWithHostNet:
+		return v1.DNSClusterFirstWithHostNet, nil
+	case DNSPolicyDefault:
+		return v1.DNSDefault, nil
+	case DNSPolicyNone:
+		return v1.DNSNone, nil
+	default:
+		return "", serrors.InvalidValueErrorf(s.DNSPolicy, "unrecognized dns policy")
+	}
+}
+
+func (c *DNSConfig) toKubeV1() *v1.PodDNSConfig {
+	if c == nil {
+		return nil
+	}
+
+	config := &v1.PodDNSConfig{
+		Nameservers: c.Nameservers,
+		Searches:    c.Searches,
+	}
+	for _, opt := range c.Options {
+		config.Options = append(config.Options, v1.PodDNSConfigOption{
+			Name:  opt.Name,
+			Value: opt.Value,
+		})
+	}
+
+	return config
+}
+
+func toKubeContainersV1(containers []Container) ([]v1.Container, error) {
+	var kubeContainers []v1.Container
+	for i := range containers {
+		kubeContainer, err := containers[i].toKubeV1()
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		kubeContainers = append(kubeContainers, *kubeContainer)
+	}
+
+	return kubeContainers, nil
+}
+
+func (c *Container) toKubeV1() (*v1.Container, error) {
+	kubeContainer := &v1.Container{
+		Name:                   c.Name,
+		Image:                  c.Image,
+		Command:                c.Command,
+		Args:                   c.Args,
+		WorkingDir:             c.WorkingDir,
+		VolumeMounts:           c.VolumeMounts,
+		VolumeDevices:          c.VolumeDevices,
+		LivenessProbe:          c.LivenessProbe,
+		ReadinessProbe:         c.ReadinessProbe,
+		Lifecycle:              c.Lifecycle,
+		TerminationMessagePath: c.TerminationMessagePath,
+		SecurityContext:        c.SecurityContext.toKubeV1(),
+		Stdin:                  c.Stdin,
+		StdinOnce:              c.StdinOnce,
+		TTY:                    c.TTY,
+	}
+
+	var err error
+	kubeContainer.Ports, err = c.toKubeV1ContainerPorts()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.ports")
+	}
+	kubeContainer.Env, err = c.toKubeV1EnvVars()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.env")
+	}
+	kubeContainer.EnvFrom, err = c.toKubeV1EnvFromSources()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.envFrom")
+	}
+	kubeContainer.Resources = c.toKubeV1ResourceRequirements()
+
+	kubeContainer.ImagePullPolicy, err = c.toKubeV1PullPolicy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.pull")
+	}
+	kubeContainer.TerminationMessagePolicy, err = c.toKubeV1TerminationMessagePolicy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.terminationMessagePolicy")
+	}
+
+	return kubeContainer, nil
+}
+
+func (c *Container) toKubeV1ContainerPorts() ([]v1.ContainerPort, error) {
+	var kubePorts []v1.ContainerPort
+	for i, port := range c.Ports {
+		kubePort := v1.ContainerPort{
+			Name:          port.Name,
+			HostIP:        port.HostIP,
+			HostPort:      port.HostPort,
+			ContainerPort: port.ContainerPort,
+		}
+
+		switch port.Protocol {
+		case ProtocolUnset:
+			kubePort.Protocol = ""
+		case ProtocolTCP:
+			kubePort.Protocol = v1.ProtocolTCP
+		case ProtocolUDP:
+			kubePort.Protocol = v1.ProtocolUDP
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(port.Protocol, "unrecognized protocol"), "$.%d", i)
+		}
+
+		kubePorts = append(kubePorts, kubePort)
+	}
+
+	return kubePorts, nil
+}
+
+func (c *Container) toKubeV1EnvVars() ([]v1.EnvVar, error) {
+	var kubeEnv []v1.EnvVar
+	for i, e := range c.Env {
+		kubeVar := v1.EnvVar{
+			Name:  e.Name,
+			Value: e.Value,
+		}
+
+		if e.From != nil {
+			source, err := e.From.toKubeV1()
+			if err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+			}
+			kubeVar.ValueFrom = source
+		}
+
+		kubeEnv = append(kubeEnv, kubeVar)
+	}
+
+	return kubeEnv, nil
+}
+
+func (s *EnvSource) toKubeV1() (*v1.EnvVarSource, error) {
+	switch s.Type {
+	case EnvSourceConfigMap:
+		return &v1.EnvVarSource{
+			ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: s.Name},
+				Key:                  s.Key,
+				Optional:             s.Optional,
+			},
+		}, nil
+	case EnvSourceSecret:
+		return &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: s.Name},
+				Key:                  s.Key,
+				Optional:             s.Optional,
+			},
+		}, nil
+	case EnvSourceField:
+		return &v1.EnvVarSource{
+			FieldRef: &v1.ObjectFieldSelector{
+				APIVersion: s.APIVersion,
+				FieldPath:  s.Key,
+			},
+		}, nil
+	case EnvSourceResource:
+		ref := &v1.ResourceFieldSelector{
+			ContainerName: s.Name,
+			Resource:      s.Key,
+		}
+		if s.Divisor != nil {
+			ref.Divisor = *s.Divisor
+		}
+		return &v1.EnvVarSource{ResourceFieldRef: ref}, nil
+	default:
+		return nil, serrors.InvalidValueErrorf(s.Type, "unsupported env source")
+	}
+}
+
+func (c *Container) toKubeV1EnvFromSources() ([]v1.EnvFromSource, error) {
+	var kubeSources []v1.EnvFromSource
+	for i, e := range c.EnvFrom {
+		kubeSource := v1.EnvFromSource{
+			Prefix: e.Prefix,
+		}
+
+		switch e.Type {
+		case EnvSourceConfigMap:
+			kubeSource.ConfigMapRef = &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: e.Name},
+				Optional:             e.Optional,
+			}
+		case EnvSourceSecret:
+			kubeSource.SecretRef = &v1.SecretEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: e.Name},
+				Optional:             e.Optional,
+			}
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(e.Type, "unsupported env source"), "$.%d", i)
+		}
+
+		kubeSources = append(kubeSources, kubeSource)
+	}
+
+	return kubeSources, nil
+}
+
+func (c *Container) toKubeV1ResourceRequirements() v1.ResourceRequirements {
+	kubeResources := v1.ResourceRequirements{}
+	for name, r := range c.Resources {
+		if r.Request != nil {
+			if kubeResources.Requests == nil {
+				kubeResources.Requests = v1.ResourceList{}
+			}
+			kubeResources.Requests[v1.ResourceName(name)] = *r.Request
+		}
+		if r.Limit != nil {
+			if kubeResources.Limits == nil {
+				kubeResources.Limits = v1.ResourceList{}
+			}
+			kubeResources.Limits[v1.ResourceName(name)] = *r.Limit
+		}
+	}
+
+	return kubeResources
+}
+
+func (c *Container) toKubeV1PullPolicy() (v1.PullPolicy, error) {
+	switch c.Pull {
+	case PullPolicyUnset:
+		return "", nil
+	case PullPolicyAlways:
+		return v1.PullAlways, nil
+	case PullPolicyNever:
+		return v1.PullNever, nil
+	case PullPolicyIfNotPresent:
+		return v1.PullIfNotPresent, nil
+	default:
+		return "", serrors.InvalidValueErrorf(c.Pull, "unrecognized image pull policy")
+	}
+}
+
+func (c *Container) toKubeV1TerminationMessagePolicy() (v1.TerminationMessagePolicy, error) {
+	switch c.TerminationMessagePolicy {
+	case TerminationMessagePolicyUnset:
+		return "", nil
+	case TerminationMessagePolicyFile:
+		return v1.TerminationMessageReadFile, nil
+	case TerminationMessagePolicyFallbackToLogsOnError:
+		return v1.TerminationMessageFallbackToLogsOnError, nil
+	default:
+		return "", serrors.InvalidValueErrorf(c.TerminationMessagePolicy, "unrecognized termination message policy")
+	}
+}
+
+func (s *SELinux) toKubeV1() *v1.SELinuxOptions {
+	if s == nil {
+		return nil
+	}
+
+	return &v1.SELinuxOptions{
+		User:  s.User,
+		Role:  s.Role,
+		Type:  s.Type,
+		Level: s.Level,
+	}
+}
+
+func (sc *PodSecurityContext) toKubeV1() *v1.PodSecurityContext {
+	if sc == nil {
+		return nil
+	}
+
+	return &v1.PodSecurityContext{
+		SELinuxOptions:     sc.SELinux.toKubeV1(),
+		RunAsUser:          sc.RunAsUser,
+		RunAsGroup:         sc.RunAsGroup,
+		RunAsNonRoot:       sc.RunAsNonRoot,
+		SupplementalGroups: sc.SupplementalGroups,
+		FSGroup:            sc.FSGroup,
+	}
+}
+
+func (sc *SecurityContext) toKubeV1() *v1.SecurityContext {
+	if sc == nil {
+		return nil
+	}
+
+	return &v1.SecurityContext{
+		Capabilities:             sc.Capabilities,
+		Privileged:               sc.Privileged,
+		SELinuxOptions:           sc.SELinux.toKubeV1(),
+		RunAsUser:                sc.RunAsUser,
+		RunAsGroup:               sc.RunAsGroup,
+		RunAsNonRoot:             sc.RunAsNonRoot,
+		ReadOnlyRootFilesystem:   sc.ReadOnlyRootFilesystem,
+		AllowPrivilegeEscalation: sc.AllowPrivilegeEscalation,
+	}
+}
+
+func (t *Toleration) toKubeV1() (*v1.Toleration, error) {
+	kubeToleration := &v1.Toleration{
+		Key:               t.Key,
+		Value:             t.Value,
+		Operator:          v1.TolerationOpEqual,
+		TolerationSeconds: t.TolerationSeconds,
+	}
+	if t.Exists {
+		kubeToleration.Operator = v1.TolerationOpExists
+	}
+
+	switch t.Effect {
+	case TaintEffectAll:
+		kubeToleration.Effect = ""
+	case TaintEffectNoSchedule:
+		kubeToleration.Effect = v1.TaintEffectNoSchedule
+	case TaintEffectPreferNoSchedule:
+		kubeToleration.Effect = v1.TaintEffectPreferNoSchedule
+	case TaintEffectNoExecute:
+		kubeToleration.Effect = v1.TaintEffectNoExecute
+	default:
+		return nil, serrors.InvalidValueErrorf(t.Effect, "unsupported taint effect")
+	}
+
+	return kubeToleration, nil
+}
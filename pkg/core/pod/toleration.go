@@ -0,0 +1,100 @@
+package pod
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+type TaintEffect string
+
+const (
+	TaintEffectAll              TaintEffect = ""
+	TaintEffectNoSchedule       TaintEffect = "no-schedule"
+	TaintEffectPreferNoSchedule TaintEffect = "prefer-no-schedule"
+	TaintEffectNoExecute        TaintEffect = "no-execute"
+)
+
+// TolerationKeyAll matches every taint key.  Only valid when the
+// toleration doesn't specify a value.
+const TolerationKeyAll = "*"
+
+// Toleration allows a pod to be scheduled onto nodes with matching taints.
+// It is written as "key[=value][:effect[:seconds]]":
+//
+//	"dedicated=gpu:no-schedule"  tolerates the taint dedicated=gpu
+//	"dedicated:no-schedule"      tolerates any value of dedicated
+//	"*"                          tolerates every taint
+//	"node.kubernetes.io/unreachable:no-execute:300"
+//
+// A key without "=" matches any value, a key with "=" matches the value
+// exactly, even if it is empty.
+type Toleration struct {
+	Key               string
+	Exists            bool
+	Value             string
+	Effect            TaintEffect
+	TolerationSeconds *int64
+}
+
+func (t *Toleration) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form key[=value][:effect[:seconds]]")
+	}
+
+	segments := strings.Split(str, ":")
+	if len(segments) > 3 {
+		return serrors.InvalidValueErrorf(str, "expected at most three toleration segments, key[=value][:effect[:seconds]]")
+	}
+
+	if fields := strings.SplitN(segments[0], "=", 2); len(fields) == 2 {
+		t.Key = fields[0]
+		t.Value = fields[1]
+	} else {
+		t.Exists = true
+		if fields[0] != TolerationKeyAll {
+			t.Key = fields[0]
+		}
+	}
+
+	if len(segments) > 1 {
+		switch effect := TaintEffect(segments[1]); effect {
+		case TaintEffectAll, TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+			t.Effect = effect
+		default:
+			return serrors.InvalidValueErrorf(segments[1], "unsupported taint effect, expected one of %s, %s or %s", TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute)
+		}
+	}
+
+	if len(segments) > 2 {
+		seconds, err := strconv.ParseInt(segments[2], 10, 64)
+		if err != nil {
+			return serrors.InvalidValueErrorf(segments[2], "expected toleration seconds to be an integer")
+		}
+		t.TolerationSeconds = &seconds
+	}
+
+	return nil
+}
+
+func (t Toleration) MarshalJSON() ([]byte, error) {
+	str := t.Key
+	if !t.Exists {
+		str = fmt.Sprintf("%s=%s", t.Key, t.Value)
+	} else if len(t.Key) == 0 {
+		str = TolerationKeyAll
+	}
+
+	if t.TolerationSeconds != nil {
+		str = fmt.Sprintf("%s:%s:%d", str, t.Effect, *t.TolerationSeconds)
+	} else if len(t.Effect) > 0 {
+		str = fmt.Sprintf("%s:%s", str, t.Effect)
+	}
+
+	return json.Marshal(str)
+}
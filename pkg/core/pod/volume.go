@@ -36,9 +36,16 @@ import (
 	"github.com/koki/json"
 	"github.com/koki/json/jsonutil"
 	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
 )
 
+// Volume is a volume of a pod.  Exactly one of the volume types is set.
+// The name isn't part of the volume definition, see Volumes for how pods
+// write it.
 type Volume struct {
+	Name string
+
 	HostPath     *HostPathVolume
 	EmptyDir     *EmptyDirVolume
 	GcePD        *GcePDVolume
@@ -68,12 +75,69 @@ type Volume struct {
 	StorageOS    *StorageOSVolume
 }
 
+// Volumes are the volumes of a pod in the order they were written.  Each
+// volume is written with its name in front, e.g. "data:pvc:claim", or
+// with a "name" key in the dictionary form.
+type Volumes []Volume
+
+func (v *Volumes) UnmarshalJSON(data []byte) error {
+	items := []json.RawMessage{}
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a list of volumes")
+	}
+
+	*v = make(Volumes, len(items))
+	names := map[string]bool{}
+	for i, item := range items {
+		vol := &(*v)[i]
+		err = vol.unmarshalJSON(item, true)
+		if err != nil {
+			return serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		if names[vol.Name] {
+			return serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(vol.Name, "duplicate volume name"), "$.%d", i)
+		}
+		names[vol.Name] = true
+	}
+
+	return nil
+}
+
+func (v Volumes) MarshalJSON() ([]byte, error) {
+	items := make([]json.RawMessage, len(v))
+	for i, vol := range v {
+		data, err := vol.marshalJSON(true)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		items[i] = data
+	}
+
+	return json.Marshal(items)
+}
+
 func (v *Volume) UnmarshalJSON(data []byte) error {
+	return v.unmarshalJSON(data, false)
+}
+
+// unmarshalJSON reads a volume that starts with its name if named is set
+func (v *Volume) unmarshalJSON(data []byte, named bool) error {
 	var err error
 	str := ""
 	err = json.Unmarshal(data, &str)
 	if err == nil {
-		segments := strings.Split(str, ":")
+		segments, err := SplitSelector(str)
+		if err != nil {
+			return err
+		}
+		if named {
+			if len(segments) < 2 {
+				return serrors.InvalidValueErrorf(str, "expected name:vol_type[:vol_id]")
+			}
+			v.Name = segments[0]
+			segments = segments[1:]
+		}
 		return v.Unmarshal(nil, segments[0], segments[1:])
 	}
 
@@ -83,6 +147,14 @@ func (v *Volume) UnmarshalJSON(data []byte) error {
 		return serrors.InvalidValueErrorf(string(data), "expected either string or dictionary")
 	}
 
+	if named {
+		v.Name, err = jsonutil.GetStringEntry(obj, "name")
+		if err != nil {
+			return err
+		}
+		delete(obj, "name")
+	}
+
 	selector := []string{}
 	if val, ok := obj["vol_id"]; ok {
 		if volName, ok := val.(string); ok {
@@ -189,6 +261,11 @@ func (v *Volume) Unmarshal(obj map[string]interface{}, volType string, selector
 }
 
 func (v Volume) MarshalJSON() ([]byte, error) {
+	return v.marshalJSON(false)
+}
+
+// marshalJSON writes the volume with its name in front if named is set
+func (v Volume) marshalJSON(named bool) ([]byte, error) {
 	var marshalledVolume *MarshalledVolume
 	var err error
 	if v.HostPath != nil {
@@ -282,12 +359,19 @@ func (v Volume) MarshalJSON() ([]byte, error) {
 	}
 
 	if len(marshalledVolume.ExtraFields) == 0 {
-		segments := []string{marshalledVolume.Type}
+		segments := []string{}
+		if named {
+			segments = append(segments, v.Name)
+		}
+		segments = append(segments, marshalledVolume.Type)
 		segments = append(segments, marshalledVolume.Selector...)
-		return json.Marshal(strings.Join(segments, ":"))
+		return json.Marshal(JoinSelector(segments))
 	}
 
 	obj := marshalledVolume.ExtraFields
+	if named {
+		obj["name"] = v.Name
+	}
 	obj["vol_type"] = marshalledVolume.Type
 	if len(marshalledVolume.Selector) > 0 {
 		obj["vol_id"] = strings.Join(marshalledVolume.Selector, ":")
@@ -296,20 +380,172 @@ func (v Volume) MarshalJSON() ([]byte, error) {
 	return json.Marshal(obj)
 }
 
-func (v *Volume) ToKube(version string) (interface{}, interface{}) {
+func (v *Volume) ToKube(version string) (interface{}, error) {
 	fields := reflect.ValueOf(v).Elem()
 	for n := 0; n < fields.NumField(); n++ {
 		field := fields.Field(n)
-		if field.IsValid() && !field.IsNil() {
-			convFunc := field.MethodByName("ToKube")
-			resp := convFunc.Call([]reflect.Value{reflect.ValueOf(version)})
-			return resp[0].Interface(), resp[1].Interface()
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+
+		convFunc := field.MethodByName("ToKube")
+		resp := convFunc.Call([]reflect.Value{reflect.ValueOf(version)})
+		if err, _ := resp[1].Interface().(error); err != nil {
+			return nil, err
+		}
+
+		kubeVolume := resp[0].Interface()
+		if vol, ok := kubeVolume.(*v1.Volume); ok {
+			vol.Name = v.Name
 		}
+		return kubeVolume, nil
 	}
 
 	return nil, fmt.Errorf("no volume type set")
 }
 
+// NewVolumeFromKubeVolume will create a new Volume object with the data
+// from a provided kubernetes volume object.  The volume must set exactly
+// one volume source.
 func NewVolumeFromKubeVolume(obj interface{}) (*Volume, error) {
-	return nil, nil
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.Volume{}):
+		o := obj.(v1.Volume)
+		return fromKubeVolumeV1(&o)
+	case reflect.TypeOf(&v1.Volume{}):
+		return fromKubeVolumeV1(obj.(*v1.Volume))
+	default:
+		return nil, fmt.Errorf("unknown Volume version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeVolumeV1(kubeVolume *v1.Volume) (*Volume, error) {
+	v := &Volume{
+		Name: kubeVolume.Name,
+	}
+	source := kubeVolume.VolumeSource
+	sources := []string{}
+	var err error
+
+	if source.HostPath != nil {
+		sources = append(sources, VolumeTypeHostPath)
+		v.HostPath, err = NewHostPathVolumeFromKubeHostPathVolumeSource(source.HostPath)
+	}
+	if err == nil && source.EmptyDir != nil {
+		sources = append(sources, VolumeTypeEmptyDir)
+		v.EmptyDir, err = NewEmptyDirVolumeFromKubeEmptyDirVolumeSource(source.EmptyDir)
+	}
+	if err == nil && source.GCEPersistentDisk != nil {
+		sources = append(sources, VolumeTypeGcePD)
+		v.GcePD, err = NewGcePDVolumeFromKubeGCEPersistentDiskVolumeSource(source.GCEPersistentDisk)
+	}
+	if err == nil && source.AWSElasticBlockStore != nil {
+		sources = append(sources, VolumeTypeAwsEBS)
+		v.AwsEBS, err = NewAwsEBSVolumeFromKubeAWSElasticBlockStoreVolumeSource(source.AWSElasticBlockStore)
+	}
+	if err == nil && source.GitRepo != nil {
+		sources = append(sources, VolumeTypeGit)
+		v.Git, err = NewGitVolumeFromKubeGitRepoVolumeSource(source.GitRepo)
+	}
+	if err == nil && source.Secret != nil {
+		sources = append(sources, VolumeTypeSecret)
+		v.Secret, err = NewSecretVolumeFromKubeSecretVolumeSource(source.Secret)
+	}
+	if err == nil && source.NFS != nil {
+		sources = append(sources, VolumeTypeNFS)
+		v.NFS, err = NewNFSVolumeFromNFSVolumeSource(source.NFS)
+	}
+	if err == nil && source.ISCSI != nil {
+		sources = append(sources, VolumeTypeISCSI)
+		v.ISCSI, err = NewISCSIVolumeFromKubeISCSIVolumeSource(source.ISCSI)
+	}
+	if err == nil && source.Glusterfs != nil {
+		sources = append(sources, VolumeTypeGlusterfs)
+		v.Glusterfs, err = NewGlusterfsVolumeFromKubeGlusterfsVolumeSource(source.Glusterfs)
+	}
+	if err == nil && source.PersistentVolumeClaim != nil {
+		sources = append(sources, VolumeTypePVC)
+		v.PVC, err = NewPVCVolumeFromKubePersistentVolumeClaimVolumeSource(source.PersistentVolumeClaim)
+	}
+	if err == nil && source.RBD != nil {
+		sources = append(sources, VolumeTypeRBD)
+		v.RBD, err = NewRBDVolumeFromKubeRBDVolumeSource(source.RBD)
+	}
+	if err == nil && source.FlexVolume != nil {
+		sources = append(sources, VolumeTypeFlex)
+		v.Flex, err = NewFlexVolumeFromKubeFlexVolumeSource(source.FlexVolume)
+	}
+	if err == nil && source.Cinder != nil {
+		sources = append(sources, VolumeTypeCinder)
+		v.Cinder, err = NewCinderVolumeFromKubeCinderVolumeSource(source.Cinder)
+	}
+	if err == nil && source.CephFS != nil {
+		sources = append(sources, VolumeTypeCephFS)
+		v.CephFS, err = NewCephFSVolumeFromKubeCephFSVolumeSource(source.CephFS)
+	}
+	if err == nil && source.Flocker != nil {
+		sources = append(sources, VolumeTypeFlocker)
+		v.Flocker, err = NewFlockerVolumeFromKubeFlockerVolumeSource(source.Flocker)
+	}
+	if err == nil && source.DownwardAPI != nil {
+		sources = append(sources, VolumeTypeDownwardAPI)
+		v.DownwardAPI, err = NewDownwardAPIVolumeFromKubeDownwardAPIVolumeSource(source.DownwardAPI)
+	}
+	if err == nil && source.FC != nil {
+		sources = append(sources, VolumeTypeFibreChannel)
+		v.FibreChannel, err = NewFibreChannelVolumeFromKubeFCVolumeSource(source.FC)
+	}
+	if err == nil && source.AzureFile != nil {
+		sources = append(sources, VolumeTypeAzureFile)
+		v.AzureFile, err = NewAzureFileVolumeFromAzureFileVolumeSource(source.AzureFile)
+	}
+	if err == nil && source.ConfigMap != nil {
+		sources = append(sources, VolumeTypeConfigMap)
+		v.ConfigMap, err = NewConfigMapVolumeFromKubeConfigMapVolumeSource(source.ConfigMap)
+	}
+	if err == nil && source.VsphereVolume != nil {
+		sources = append(sources, VolumeTypeVsphere)
+		v.Vsphere, err = NewVsphereVolumeFromKubeVsphereVirtualDiskVolumeSource(source.VsphereVolume)
+	}
+	if err == nil && source.Quobyte != nil {
+		sources = append(sources, VolumeTypeQuobyte)
+		v.Quobyte, err = NewQuobyteVolumeFromKubeQuobyteVolumeSource(source.Quobyte)
+	}
+	if err == nil && source.AzureDisk != nil {
+		sources = append(sources, VolumeTypeAzureDisk)
+		v.AzureDisk, err = NewAzureDiskVolumeFromAzureDiskVolumeSource(source.AzureDisk)
+	}
+	if err == nil && source.PhotonPersistentDisk != nil {
+		sources = append(sources, VolumeTypePhotonPD)
+		v.PhotonPD, err = NewPhotonPDVolumeFromKubePhotonPersistentDiskVolumeSource(source.PhotonPersistentDisk)
+	}
+	if err == nil && source.Projected != nil {
+		sources = append(sources, VolumeTypeProjected)
+		v.Projected, err = NewProjectedVolumeFromKubeProjectedVolumeSource(source.Projected)
+	}
+	if err == nil && source.PortworxVolume != nil {
+		sources = append(sources, VolumeTypePortworx)
+		v.Portworx, err = NewPortworxVolumeVolumeFromKubePortworxVolumeSource(source.PortworxVolume)
+	}
+	if err == nil && source.ScaleIO != nil {
+		sources = append(sources, VolumeTypeScaleIO)
+		v.ScaleIO, err = NewScaleIOVolumeFromKubeScaleIOVolumeSource(source.ScaleIO)
+	}
+	if err == nil && source.StorageOS != nil {
+		sources = append(sources, VolumeTypeStorageOS)
+		v.StorageOS, err = NewStorageOSVolumeFromKubeStorageOSVolumeSource(source.StorageOS)
+	}
+
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "%s", sources[len(sources)-1])
+	}
+
+	switch len(sources) {
+	case 0:
+		return nil, serrors.InvalidInstanceErrorf(kubeVolume, "volume %s has no volume source", kubeVolume.Name)
+	case 1:
+		return v, nil
+	default:
+		return nil, serrors.InvalidInstanceErrorf(kubeVolume, "volume %s has more than one volume source: %s", kubeVolume.Name, strings.Join(sources, ", "))
+	}
 }
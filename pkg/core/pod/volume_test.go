@@ -7,6 +7,8 @@ import (
 
 	. "mantle/internal/pkg/core/pod/volume/pvc"
 
+	"github.com/koki/json"
+
 	"k8s.io/api/core/v1"
 )
 
@@ -48,3 +50,178 @@ func TestVolumeToKube(t *testing.T) {
 		}
 	}
 }
+
+func TestNewVolumeFromKubeVolume(t *testing.T) {
+	sources := map[string]v1.VolumeSource{
+		"HostPath":     {HostPath: &v1.HostPathVolumeSource{}},
+		"EmptyDir":     {EmptyDir: &v1.EmptyDirVolumeSource{}},
+		"GcePD":        {GCEPersistentDisk: &v1.GCEPersistentDiskVolumeSource{}},
+		"AwsEBS":       {AWSElasticBlockStore: &v1.AWSElasticBlockStoreVolumeSource{}},
+		"Git":          {GitRepo: &v1.GitRepoVolumeSource{}},
+		"Secret":       {Secret: &v1.SecretVolumeSource{}},
+		"NFS":          {NFS: &v1.NFSVolumeSource{}},
+		"ISCSI":        {ISCSI: &v1.ISCSIVolumeSource{}},
+		"Glusterfs":    {Glusterfs: &v1.GlusterfsVolumeSource{}},
+		"PVC":          {PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{}},
+		"RBD":          {RBD: &v1.RBDVolumeSource{}},
+		"Flex":         {FlexVolume: &v1.FlexVolumeSource{}},
+		"Cinder":       {Cinder: &v1.CinderVolumeSource{}},
+		"CephFS":       {CephFS: &v1.CephFSVolumeSource{}},
+		"Flocker":      {Flocker: &v1.FlockerVolumeSource{}},
+		"DownwardAPI":  {DownwardAPI: &v1.DownwardAPIVolumeSource{}},
+		"FibreChannel": {FC: &v1.FCVolumeSource{}},
+		"AzureFile":    {AzureFile: &v1.AzureFileVolumeSource{}},
+		"ConfigMap":    {ConfigMap: &v1.ConfigMapVolumeSource{}},
+		"Vsphere":      {VsphereVolume: &v1.VsphereVirtualDiskVolumeSource{}},
+		"Quobyte":      {Quobyte: &v1.QuobyteVolumeSource{}},
+		"AzureDisk":    {AzureDisk: &v1.AzureDiskVolumeSource{}},
+		"PhotonPD":     {PhotonPersistentDisk: &v1.PhotonPersistentDiskVolumeSource{}},
+		"Projected":    {Projected: &v1.ProjectedVolumeSource{}},
+		"Portworx":     {PortworxVolume: &v1.PortworxVolumeSource{}},
+		"ScaleIO":      {ScaleIO: &v1.ScaleIOVolumeSource{}},
+		"StorageOS":    {StorageOS: &v1.StorageOSVolumeSource{}},
+	}
+
+	// every field but the name is a volume type
+	if n := reflect.TypeOf(Volume{}).NumField() - 1; len(sources) != n {
+		t.Errorf("expected a test case for each of the %d volume types, got %d", n, len(sources))
+	}
+
+	for field, source := range sources {
+		vol, err := NewVolumeFromKubeVolume(v1.Volume{Name: "test", VolumeSource: source})
+		// config maps can't be unnamed in either direction
+		if field == "ConfigMap" {
+			if err == nil {
+				t.Errorf("%s: expected an error converting without a config name", field)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: conversion failed: %v", field, err)
+			continue
+		}
+
+		if vol.Name != "test" {
+			t.Errorf("%s: expected name test, got %s", field, vol.Name)
+		}
+
+		value := reflect.ValueOf(vol).Elem()
+		for n := 0; n < value.NumField(); n++ {
+			name := value.Type().Field(n).Name
+			if name == "Name" {
+				continue
+			}
+			if set := !value.Field(n).IsNil(); set != (name == field) {
+				t.Errorf("%s: unexpected value for %s", field, name)
+			}
+		}
+
+		obj, err := vol.ToKube("v1")
+		if err != nil {
+			t.Errorf("%s: conversion back failed: %v", field, err)
+			continue
+		}
+		if kubeVol, ok := obj.(*v1.Volume); !ok || kubeVol.Name != "test" {
+			t.Errorf("%s: expected a v1 volume named test, got %#v", field, obj)
+		}
+	}
+}
+
+func TestNewVolumeFromKubeVolumeSources(t *testing.T) {
+	testcases := []struct {
+		name   string
+		source v1.VolumeSource
+	}{
+		{
+			name:   "no volume source",
+			source: v1.VolumeSource{},
+		},
+		{
+			name: "multiple volume sources",
+			source: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+				HostPath: &v1.HostPathVolumeSource{},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := NewVolumeFromKubeVolume(&v1.Volume{Name: "test", VolumeSource: tc.source})
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestVolumeStringRoundTrip(t *testing.T) {
+	testcases := []struct {
+		vol v1.Volume
+		str string
+	}{
+		{
+			vol: v1.Volume{VolumeSource: v1.VolumeSource{
+				NFS: &v1.NFSVolumeSource{Server: "fd00::1", Path: "/export"},
+			}},
+			str: `"nfs:\"fd00::1\":/export"`,
+		},
+		{
+			vol: v1.Volume{VolumeSource: v1.VolumeSource{
+				GitRepo: &v1.GitRepoVolumeSource{Repository: "https://example.com/repo.git"},
+			}},
+			str: `"git:\"https://example.com/repo.git\""`,
+		},
+	}
+
+	for _, tc := range testcases {
+		vol, err := NewVolumeFromKubeVolume(tc.vol)
+		if err != nil {
+			t.Fatalf("%s: conversion failed: %v", tc.str, err)
+		}
+
+		data, err := json.Marshal(vol)
+		if err != nil {
+			t.Errorf("%s: marshal failed: %v", tc.str, err)
+		}
+		if string(data) != tc.str {
+			t.Errorf("expected %s, got %s", tc.str, data)
+		}
+
+		parsed := Volume{}
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			t.Errorf("%s: unmarshal failed: %v", tc.str, err)
+		}
+		if !reflect.DeepEqual(&parsed, vol) {
+			t.Errorf("%s: round trip changed the volume", tc.str)
+		}
+	}
+}
+
+func TestVolumesKeepOrder(t *testing.T) {
+	str := `["data:pvc:claim",{"medium":"memory","name":"cache","vol_type":"empty_dir"}]`
+
+	vols := Volumes{}
+	if err := json.Unmarshal([]byte(str), &vols); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(vols) != 2 || vols[0].Name != "data" || vols[1].Name != "cache" {
+		t.Fatalf("expected volumes data and cache in order, got %#v", vols)
+	}
+
+	data, err := json.Marshal(vols)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if string(data) != str {
+		t.Errorf("expected %s, got %s", str, data)
+	}
+
+	for _, invalid := range []string{
+		`["pvc"]`,
+		`[{"vol_type":"empty_dir"}]`,
+		`["data:pvc:claim","data:empty_dir"]`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &Volumes{}); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
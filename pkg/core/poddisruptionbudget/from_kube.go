@@ -0,0 +1,39 @@
+package poddisruptionbudget
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/pkg/core/selector"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+)
+
+// NewPodDisruptionBudgetFromKubePodDisruptionBudget will create a new
+// PodDisruptionBudget object with the data from a provided kubernetes pod
+// disruption budget object of any supported api version
+func NewPodDisruptionBudgetFromKubePodDisruptionBudget(obj interface{}) (*PodDisruptionBudget, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(policyv1beta1.PodDisruptionBudget{}):
+		o := obj.(policyv1beta1.PodDisruptionBudget)
+		return fromKubePodDisruptionBudgetV1beta1(&o)
+	case reflect.TypeOf(&policyv1beta1.PodDisruptionBudget{}):
+		return fromKubePodDisruptionBudgetV1beta1(obj.(*policyv1beta1.PodDisruptionBudget))
+	default:
+		return nil, fmt.Errorf("unknown PodDisruptionBudget version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubePodDisruptionBudgetV1beta1(kubeBudget *policyv1beta1.PodDisruptionBudget) (*PodDisruptionBudget, error) {
+	return &PodDisruptionBudget{
+		Name:           kubeBudget.Name,
+		Namespace:      kubeBudget.Namespace,
+		Version:        kubeBudget.APIVersion,
+		Cluster:        kubeBudget.ClusterName,
+		Labels:         kubeBudget.Labels,
+		Annotations:    kubeBudget.Annotations,
+		Selector:       selector.NewLabelSelectorFromKubeLabelSelectorV1(kubeBudget.Spec.Selector),
+		MinAvailable:   kubeBudget.Spec.MinAvailable,
+		MaxUnavailable: kubeBudget.Spec.MaxUnavailable,
+	}, nil
+}
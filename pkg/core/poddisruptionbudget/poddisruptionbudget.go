@@ -0,0 +1,22 @@
+package poddisruptionbudget
+
+import (
+	"mantle/internal/pkg/core/selector"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PodDisruptionBudget defines a pod disruption budget object.  The status
+// of the budget is dropped.
+type PodDisruptionBudget struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Selector       *selector.LabelSelector `json:"selector,omitempty"`
+	MinAvailable   *intstr.IntOrString     `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString     `json:"maxUnavailable,omitempty"`
+}
@@ -0,0 +1,62 @@
+package poddisruptionbudget
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestPodDisruptionBudgetRoundTrip(t *testing.T) {
+	maxUnavailable := intstr.FromString("25%")
+	kubeBudget := &policyv1beta1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy/v1beta1",
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "testNS",
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+			},
+			MaxUnavailable: &maxUnavailable,
+		},
+		Status: policyv1beta1.PodDisruptionBudgetStatus{ExpectedPods: 4},
+	}
+
+	b, err := NewPodDisruptionBudgetFromKubePodDisruptionBudget(kubeBudget)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	expected := `{"version":"policy/v1beta1","name":"web","namespace":"testNS","selector":"app=web","maxUnavailable":"25%"}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	b = &PodDisruptionBudget{}
+	if err := json.Unmarshal(data, b); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := b.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+
+	kubeBudget.Status = policyv1beta1.PodDisruptionBudgetStatus{}
+	if !reflect.DeepEqual(obj, kubeBudget) {
+		t.Errorf("round trip changed the budget\nexpected %#v\ngot      %#v", kubeBudget, obj)
+	}
+}
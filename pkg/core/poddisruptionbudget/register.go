@@ -0,0 +1,30 @@
+package poddisruptionbudget
+
+import (
+	"mantle/pkg/registry"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "pod_disruption_budget"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			policyv1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"),
+		},
+		New: func() registry.Object {
+			return &PodDisruptionBudget{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			b, err := NewPodDisruptionBudgetFromKubePodDisruptionBudget(obj)
+			if err != nil {
+				return nil, err
+			}
+			return b, nil
+		},
+	})
+}
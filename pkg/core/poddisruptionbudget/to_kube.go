@@ -0,0 +1,40 @@
+package poddisruptionbudget
+
+import (
+	"fmt"
+	"strings"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes pod disruption budget object of the api
+// version type defined in the object
+func (b *PodDisruptionBudget) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(b.Version) {
+	case "policy/v1beta1":
+		return b.toKubeV1beta1()
+	case "":
+		return b.toKubeV1beta1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for pod disruption budget: %s", b.Version)
+	}
+}
+
+func (b *PodDisruptionBudget) toKubeV1beta1() (*policyv1beta1.PodDisruptionBudget, error) {
+	kubeBudget := &policyv1beta1.PodDisruptionBudget{}
+	kubeBudget.Name = b.Name
+	kubeBudget.Namespace = b.Namespace
+	kubeBudget.APIVersion = "policy/v1beta1"
+	kubeBudget.ClusterName = b.Cluster
+	kubeBudget.Kind = "PodDisruptionBudget"
+	kubeBudget.Labels = b.Labels
+	kubeBudget.Annotations = b.Annotations
+	kubeBudget.Spec = policyv1beta1.PodDisruptionBudgetSpec{
+		Selector:       b.Selector.ToKubeLabelSelectorV1(),
+		MinAvailable:   b.MinAvailable,
+		MaxUnavailable: b.MaxUnavailable,
+	}
+
+	return kubeBudget, nil
+}
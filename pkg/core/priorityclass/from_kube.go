@@ -0,0 +1,37 @@
+package priorityclass
+
+import (
+	"fmt"
+	"reflect"
+
+	schedulingv1alpha1 "k8s.io/api/scheduling/v1alpha1"
+)
+
+// NewPriorityClassFromKubePriorityClass will create a new PriorityClass
+// object with the data from a provided kubernetes priority class object of
+// any supported api version
+func NewPriorityClassFromKubePriorityClass(obj interface{}) (*PriorityClass, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(schedulingv1alpha1.PriorityClass{}):
+		o := obj.(schedulingv1alpha1.PriorityClass)
+		return fromKubePriorityClassV1alpha1(&o)
+	case reflect.TypeOf(&schedulingv1alpha1.PriorityClass{}):
+		return fromKubePriorityClassV1alpha1(obj.(*schedulingv1alpha1.PriorityClass))
+	default:
+		return nil, fmt.Errorf("unknown PriorityClass version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubePriorityClassV1alpha1(kubeClass *schedulingv1alpha1.PriorityClass) (*PriorityClass, error) {
+	return &PriorityClass{
+		Name:          kubeClass.Name,
+		Namespace:     kubeClass.Namespace,
+		Version:       kubeClass.APIVersion,
+		Cluster:       kubeClass.ClusterName,
+		Labels:        kubeClass.Labels,
+		Annotations:   kubeClass.Annotations,
+		Value:         kubeClass.Value,
+		GlobalDefault: kubeClass.GlobalDefault,
+		Description:   kubeClass.Description,
+	}, nil
+}
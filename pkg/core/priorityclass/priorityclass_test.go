@@ -0,0 +1,48 @@
+package priorityclass
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	schedulingv1alpha1 "k8s.io/api/scheduling/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPriorityClassRoundTrip(t *testing.T) {
+	kubeClass := &schedulingv1alpha1.PriorityClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "scheduling.k8s.io/v1alpha1",
+			Kind:       "PriorityClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "high",
+		},
+		Value:         100000,
+		GlobalDefault: true,
+		Description:   "for critical services",
+	}
+
+	c, err := NewPriorityClassFromKubePriorityClass(kubeClass)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	c = &PriorityClass{}
+	if err := json.Unmarshal(data, c); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := c.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeClass) {
+		t.Errorf("round trip changed the priority class\nexpected %#v\ngot      %#v", kubeClass, obj)
+	}
+}
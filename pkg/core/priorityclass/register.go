@@ -0,0 +1,30 @@
+package priorityclass
+
+import (
+	"mantle/pkg/registry"
+
+	schedulingv1alpha1 "k8s.io/api/scheduling/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "priority_class"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			schedulingv1alpha1.SchemeGroupVersion.WithKind("PriorityClass"),
+		},
+		New: func() registry.Object {
+			return &PriorityClass{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			c, err := NewPriorityClassFromKubePriorityClass(obj)
+			if err != nil {
+				return nil, err
+			}
+			return c, nil
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package priorityclass
+
+import (
+	"fmt"
+	"strings"
+
+	schedulingv1alpha1 "k8s.io/api/scheduling/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes priority class object of the api version
+// type defined in the object
+func (c *PriorityClass) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(c.Version) {
+	case "scheduling.k8s.io/v1alpha1":
+		return c.toKubeV1alpha1()
+	case "":
+		return c.toKubeV1alpha1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for priority class: %s", c.Version)
+	}
+}
+
+func (c *PriorityClass) toKubeV1alpha1() (*schedulingv1alpha1.PriorityClass, error) {
+	kubeClass := &schedulingv1alpha1.PriorityClass{}
+	kubeClass.Name = c.Name
+	kubeClass.Namespace = c.Namespace
+	kubeClass.APIVersion = "scheduling.k8s.io/v1alpha1"
+	kubeClass.ClusterName = c.Cluster
+	kubeClass.Kind = "PriorityClass"
+	kubeClass.Labels = c.Labels
+	kubeClass.Annotations = c.Annotations
+	kubeClass.Value = c.Value
+	kubeClass.GlobalDefault = c.GlobalDefault
+	kubeClass.Description = c.Description
+
+	return kubeClass, nil
+}
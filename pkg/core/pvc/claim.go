@@ -0,0 +1,255 @@
+package pvc
+
+import (
+	"strings"
+
+	"mantle/internal/pkg/core/selector"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type AccessMode string
+
+const (
+	AccessModeReadWriteOnce AccessMode = "rwo"
+	AccessModeReadOnlyMany  AccessMode = "rox"
+	AccessModeReadWriteMany AccessMode = "rwx"
+)
+
+// AccessModes are written as a comma separated list, e.g. "rwo,rox"
+type AccessModes []AccessMode
+
+type VolumeMode string
+
+const (
+	VolumeModeUnset      VolumeMode = ""
+	VolumeModeBlock      VolumeMode = "block"
+	VolumeModeFilesystem VolumeMode = "filesystem"
+)
+
+const claimSeparator = ":"
+
+// ClaimSpec is the storage requested by a persistent volume claim.  Claims
+// that only set the size, access modes and storage class can be written
+// as "size[:accessModes[:storageClass]]", e.g. "10Gi:rwo:fast".  An empty
+// storage class, which disables dynamic provisioning, is written as a
+// trailing ":".
+type ClaimSpec struct {
+	Size         *resource.Quantity      `json:"size,omitempty"`
+	Limit        *resource.Quantity      `json:"limit,omitempty"`
+	AccessModes  AccessModes             `json:"accessModes,omitempty"`
+	StorageClass *string                 `json:"storageClass,omitempty"`
+	Selector     *selector.LabelSelector `json:"selector,omitempty"`
+	VolumeName   string                  `json:"volumeName,omitempty"`
+	VolumeMode   VolumeMode              `json:"volumeMode,omitempty"`
+}
+
+// ClaimTemplate is a claim created for each pod of a stateful set.  It is
+// written in the ClaimSpec shorthand unless it needs any other fields.
+// Named templates are written in a list, see ClaimTemplates.
+type ClaimTemplate struct {
+	Name        string            `json:"name,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	ClaimSpec
+}
+
+func (m *AccessModes) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a comma separated list of access modes")
+	}
+
+	return m.Unmarshal(str)
+}
+
+func (m *AccessModes) Unmarshal(str string) error {
+	*m = nil
+	if len(str) == 0 {
+		return nil
+	}
+
+	for _, mode := range strings.Split(str, ",") {
+		switch accessMode := AccessMode(mode); accessMode {
+		case AccessModeReadWriteOnce, AccessModeReadOnlyMany, AccessModeReadWriteMany:
+			*m = append(*m, accessMode)
+		default:
+			return serrors.InvalidValueErrorf(mode, "unsupported access mode, expected %s, %s or %s", AccessModeReadWriteOnce, AccessModeReadOnlyMany, AccessModeReadWriteMany)
+		}
+	}
+
+	return nil
+}
+
+func (m AccessModes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+func (m AccessModes) String() string {
+	modes := make([]string, len(m))
+	for i, mode := range m {
+		modes[i] = string(mode)
+	}
+
+	return strings.Join(modes, ",")
+}
+
+// Unmarshal reads the "size[:accessModes[:storageClass]]" shorthand
+func (s *ClaimSpec) Unmarshal(str string) error {
+	segments := strings.Split(str, claimSeparator)
+	if len(segments) > 3 {
+		return serrors.InvalidValueErrorf(str, "expected size[:accessModes[:storageClass]]")
+	}
+
+	if len(segments[0]) > 0 {
+		size, err := resource.ParseQuantity(segments[0])
+		if err != nil {
+			return serrors.InvalidValueErrorf(segments[0], "invalid size: %s", err)
+		}
+		s.Size = &size
+	}
+	if len(segments) > 1 {
+		err := s.AccessModes.Unmarshal(segments[1])
+		if err != nil {
+			return err
+		}
+	}
+	if len(segments) > 2 {
+		storageClass := segments[2]
+		s.StorageClass = &storageClass
+	}
+
+	return nil
+}
+
+// Marshal returns the shorthand for the claim, or false if the claim sets
+// fields that the shorthand can't express
+func (s ClaimSpec) Marshal() (string, bool) {
+	if s.Limit != nil || s.Selector != nil || len(s.VolumeName) > 0 || len(s.VolumeMode) > 0 {
+		return "", false
+	}
+
+	segments := []string{""}
+	if s.Size != nil {
+		segments[0] = s.Size.String()
+	}
+	if len(s.AccessModes) > 0 || s.StorageClass != nil {
+		segments = append(segments, s.AccessModes.String())
+	}
+	if s.StorageClass != nil {
+		if strings.Contains(*s.StorageClass, claimSeparator) {
+			return "", false
+		}
+		segments = append(segments, *s.StorageClass)
+	}
+
+	return strings.Join(segments, claimSeparator), true
+}
+
+// claimTemplate has the fields of ClaimTemplate without its json methods
+type claimTemplate ClaimTemplate
+
+func (t *ClaimTemplate) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		return t.ClaimSpec.Unmarshal(str)
+	}
+
+	obj := claimTemplate{}
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*t = ClaimTemplate(obj)
+	return nil
+}
+
+func (t ClaimTemplate) MarshalJSON() ([]byte, error) {
+	if len(t.Name) == 0 && len(t.Labels) == 0 && len(t.Annotations) == 0 {
+		if str, ok := t.ClaimSpec.Marshal(); ok {
+			return json.Marshal(str)
+		}
+	}
+
+	return json.Marshal(claimTemplate(t))
+}
+
+// ClaimTemplates are the named claim templates of a stateful set in the
+// order they were written.  Each template is written as
+// "name[:size[:accessModes[:storageClass]]]", e.g. "data:10Gi:rwo:fast",
+// or as a dictionary with a "name" key.
+type ClaimTemplates []ClaimTemplate
+
+func (t *ClaimTemplates) UnmarshalJSON(data []byte) error {
+	items := []json.RawMessage{}
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a list of claim templates")
+	}
+
+	*t = make(ClaimTemplates, len(items))
+	names := map[string]bool{}
+	for i, item := range items {
+		claim := &(*t)[i]
+		err = claim.unmarshalNamedJSON(item)
+		if err != nil {
+			return serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		if names[claim.Name] {
+			return serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(claim.Name, "duplicate claim template name"), "$.%d", i)
+		}
+		names[claim.Name] = true
+	}
+
+	return nil
+}
+
+func (t *ClaimTemplate) unmarshalNamedJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		segments := strings.SplitN(str, claimSeparator, 2)
+		t.Name = segments[0]
+		if len(segments) > 1 {
+			err = t.ClaimSpec.Unmarshal(segments[1])
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		err = t.UnmarshalJSON(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(t.Name) == 0 {
+		return serrors.InvalidValueErrorf(string(data), "claim template name is required")
+	}
+	return nil
+}
+
+func (t ClaimTemplates) MarshalJSON() ([]byte, error) {
+	items := make([]interface{}, len(t))
+	for i, claim := range t {
+		items[i] = claim
+		if len(claim.Labels) > 0 || len(claim.Annotations) > 0 {
+			continue
+		}
+		if str, ok := claim.ClaimSpec.Marshal(); ok {
+			if len(str) > 0 {
+				str = claimSeparator + str
+			}
+			items[i] = claim.Name + str
+		}
+	}
+
+	return json.Marshal(items)
+}
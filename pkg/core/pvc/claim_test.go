@@ -0,0 +1,148 @@
+package pvc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClaimTemplateShorthand(t *testing.T) {
+	testcases := []struct {
+		str  string
+		pass bool
+	}{
+		{str: "10Gi", pass: true},
+		{str: "10Gi:rwo", pass: true},
+		{str: "10Gi:rwo,rox:fast", pass: true},
+		{str: "10Gi::fast", pass: true},
+		{str: "10Gi:rwo:", pass: true},
+		{str: "ten:rwo", pass: false},
+		{str: "10Gi:rw", pass: false},
+		{str: "10Gi:rwo:fast:extra", pass: false},
+	}
+
+	for _, tc := range testcases {
+		claim := ClaimTemplate{}
+		err := json.Unmarshal([]byte(`"`+tc.str+`"`), &claim)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+			continue
+		}
+		if !tc.pass {
+			continue
+		}
+
+		data, err := json.Marshal(claim)
+		if err != nil {
+			t.Errorf("%s: marshal failed: %v", tc.str, err)
+		} else if string(data) != `"`+tc.str+`"` {
+			t.Errorf("%s: round trip produced %s", tc.str, data)
+		}
+	}
+}
+
+func TestClaimTemplateDictionary(t *testing.T) {
+	data := []byte(`{"size":"5Gi","accessModes":"rwx","volumeMode":"block","labels":{"app":"db"}}`)
+	claim := ClaimTemplate{}
+	if err := json.Unmarshal(data, &claim); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if claim.VolumeMode != VolumeModeBlock || claim.Labels["app"] != "db" {
+		t.Errorf("unexpected claim %#v", claim)
+	}
+
+	out, err := json.Marshal(claim)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if string(out) != `{"labels":{"app":"db"},"size":"5Gi","accessModes":"rwx","volumeMode":"block"}` {
+		t.Errorf("unexpected output %s", out)
+	}
+}
+
+func TestClaimTemplates(t *testing.T) {
+	str := `["data:10Gi:rwo:fast","conf",{"name":"logs","labels":{"app":"db"},"size":"1Gi"}]`
+
+	claims := ClaimTemplates{}
+	if err := json.Unmarshal([]byte(str), &claims); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(claims) != 3 || claims[0].Name != "data" || claims[1].Name != "conf" || claims[2].Name != "logs" {
+		t.Fatalf("expected claims data, conf and logs in order, got %#v", claims)
+	}
+
+	data, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if string(data) != str {
+		t.Errorf("expected %s, got %s", str, data)
+	}
+
+	for _, invalid := range []string{
+		`[":10Gi"]`,
+		`[{"size":"10Gi"}]`,
+		`["data:10Gi","data:1Gi"]`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &ClaimTemplates{}); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
+
+func TestPersistentVolumeClaimRoundTrip(t *testing.T) {
+	storageClass := "fast"
+	volumeMode := v1.PersistentVolumeBlock
+	kubeClaim := &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data",
+			Namespace: "testNS",
+			Labels:    map[string]string{"app": "db"},
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tier": "ssd"},
+			},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+			StorageClassName: &storageClass,
+			VolumeMode:       &volumeMode,
+		},
+		Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+
+	claim, err := NewPersistentVolumeClaimFromKubePersistentVolumeClaim(kubeClaim)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(claim)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	claim = &PersistentVolumeClaim{}
+	if err := json.Unmarshal(data, claim); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := claim.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+
+	kubeClaim.Status = v1.PersistentVolumeClaimStatus{}
+	if !reflect.DeepEqual(obj, kubeClaim) {
+		t.Errorf("round trip changed the claim\nexpected %#v\ngot      %#v", kubeClaim, obj)
+	}
+}
@@ -0,0 +1,178 @@
+package pvc
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/pkg/core/selector"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NewPersistentVolumeClaimFromKubePersistentVolumeClaim will create a new
+// PersistentVolumeClaim object with the data from a provided kubernetes
+// persistent volume claim object
+func NewPersistentVolumeClaimFromKubePersistentVolumeClaim(obj interface{}) (*PersistentVolumeClaim, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.PersistentVolumeClaim{}):
+		o := obj.(v1.PersistentVolumeClaim)
+		return fromKubePersistentVolumeClaimV1(&o)
+	case reflect.TypeOf(&v1.PersistentVolumeClaim{}):
+		return fromKubePersistentVolumeClaimV1(obj.(*v1.PersistentVolumeClaim))
+	default:
+		return nil, fmt.Errorf("unknown PersistentVolumeClaim version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubePersistentVolumeClaimV1(kubeClaim *v1.PersistentVolumeClaim) (*PersistentVolumeClaim, error) {
+	spec, err := fromKubeClaimSpecV1(&kubeClaim.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	return &PersistentVolumeClaim{
+		Name:        kubeClaim.Name,
+		Namespace:   kubeClaim.Namespace,
+		Version:     kubeClaim.APIVersion,
+		Cluster:     kubeClaim.ClusterName,
+		Labels:      kubeClaim.Labels,
+		Annotations: kubeClaim.Annotations,
+		ClaimSpec:   *spec,
+	}, nil
+}
+
+// NewClaimTemplateFromKubePersistentVolumeClaim will create a new
+// ClaimTemplate object with the data from a provided kubernetes persistent
+// volume claim object.  The status of the claim is dropped.
+func NewClaimTemplateFromKubePersistentVolumeClaim(obj interface{}) (*ClaimTemplate, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.PersistentVolumeClaim{}):
+		o := obj.(v1.PersistentVolumeClaim)
+		return fromKubeClaimTemplateV1(&o)
+	case reflect.TypeOf(&v1.PersistentVolumeClaim{}):
+		return fromKubeClaimTemplateV1(obj.(*v1.PersistentVolumeClaim))
+	default:
+		return nil, fmt.Errorf("unknown PersistentVolumeClaim version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeClaimTemplateV1(kubeClaim *v1.PersistentVolumeClaim) (*ClaimTemplate, error) {
+	spec, err := fromKubeClaimSpecV1(&kubeClaim.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec")
+	}
+
+	return &ClaimTemplate{
+		Name:        kubeClaim.Name,
+		Labels:      kubeClaim.Labels,
+		Annotations: kubeClaim.Annotations,
+		ClaimSpec:   *spec,
+	}, nil
+}
+
+// NewClaimSpecFromKubePersistentVolumeClaimSpec will create a new
+// ClaimSpec object with the data from a provided kubernetes persistent
+// volume claim spec object
+func NewClaimSpecFromKubePersistentVolumeClaimSpec(obj interface{}) (*ClaimSpec, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.PersistentVolumeClaimSpec{}):
+		o := obj.(v1.PersistentVolumeClaimSpec)
+		return fromKubeClaimSpecV1(&o)
+	case reflect.TypeOf(&v1.PersistentVolumeClaimSpec{}):
+		return fromKubeClaimSpecV1(obj.(*v1.PersistentVolumeClaimSpec))
+	default:
+		return nil, fmt.Errorf("unknown PersistentVolumeClaimSpec version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeClaimSpecV1(kubeSpec *v1.PersistentVolumeClaimSpec) (*ClaimSpec, error) {
+	accessModes, err := NewAccessModesFromKubeAccessModes(kubeSpec.AccessModes)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.accessModes")
+	}
+
+	volumeMode, err := NewVolumeModeFromKubeVolumeMode(kubeSpec.VolumeMode)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.volumeMode")
+	}
+
+	size, err := fromKubeStorageV1(kubeSpec.Resources.Requests)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.resources.requests")
+	}
+
+	limit, err := fromKubeStorageV1(kubeSpec.Resources.Limits)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.resources.limits")
+	}
+
+	return &ClaimSpec{
+		Size:         size,
+		Limit:        limit,
+		AccessModes:  accessModes,
+		StorageClass: kubeSpec.StorageClassName,
+		Selector:     selector.NewLabelSelectorFromKubeLabelSelectorV1(kubeSpec.Selector),
+		VolumeName:   kubeSpec.VolumeName,
+		VolumeMode:   volumeMode,
+	}, nil
+}
+
+// NewAccessModesFromKubeAccessModes converts the access modes of a
+// kubernetes persistent volume or claim
+func NewAccessModesFromKubeAccessModes(kubeModes []v1.PersistentVolumeAccessMode) (AccessModes, error) {
+	if len(kubeModes) == 0 {
+		return nil, nil
+	}
+
+	modes := make(AccessModes, len(kubeModes))
+	for i, kubeMode := range kubeModes {
+		switch kubeMode {
+		case v1.ReadWriteOnce:
+			modes[i] = AccessModeReadWriteOnce
+		case v1.ReadOnlyMany:
+			modes[i] = AccessModeReadOnlyMany
+		case v1.ReadWriteMany:
+			modes[i] = AccessModeReadWriteMany
+		default:
+			return nil, serrors.InvalidValueErrorf(kubeMode, "unrecognized access mode")
+		}
+	}
+
+	return modes, nil
+}
+
+// NewVolumeModeFromKubeVolumeMode converts the volume mode of a
+// kubernetes persistent volume or claim
+func NewVolumeModeFromKubeVolumeMode(kubeMode *v1.PersistentVolumeMode) (VolumeMode, error) {
+	if kubeMode == nil {
+		return VolumeModeUnset, nil
+	}
+
+	switch *kubeMode {
+	case v1.PersistentVolumeBlock:
+		return VolumeModeBlock, nil
+	case v1.PersistentVolumeFilesystem:
+		return VolumeModeFilesystem, nil
+	default:
+		return VolumeModeUnset, serrors.InvalidValueErrorf(*kubeMode, "unrecognized volume mode")
+	}
+}
+
+// fromKubeStorageV1 returns the storage quantity of a claim's requests or
+// limits, which can't hold any other resource
+func fromKubeStorageV1(kubeResources v1.ResourceList) (*resource.Quantity, error) {
+	for name := range kubeResources {
+		if name != v1.ResourceStorage {
+			return nil, serrors.InvalidValueErrorf(name, "only %s can be requested by a claim", v1.ResourceStorage)
+		}
+	}
+
+	if quantity, ok := kubeResources[v1.ResourceStorage]; ok {
+		return &quantity, nil
+	}
+
+	return nil, nil
+}
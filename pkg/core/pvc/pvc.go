@@ -0,0 +1,16 @@
+package pvc
+
+// PersistentVolumeClaim defines a persistent volume claim object.  The
+// claim is written with the same fields as a ClaimSpec, and the status of
+// the claim is dropped.  The pinned kubernetes api has no claim data
+// source, so claims can't be created from a snapshot or another claim.
+type PersistentVolumeClaim struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	ClaimSpec
+}
@@ -0,0 +1,30 @@
+package pvc
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "persistent_volume_claim"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"),
+		},
+		New: func() registry.Object {
+			return &PersistentVolumeClaim{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			c, err := NewPersistentVolumeClaimFromKubePersistentVolumeClaim(obj)
+			if err != nil {
+				return nil, err
+			}
+			return c, nil
+		},
+	})
+}
@@ -0,0 +1,154 @@
+package pvc
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes persistent volume claim object of the
+// api version type defined in the object
+func (c *PersistentVolumeClaim) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(c.Version) {
+	case "v1":
+		return c.toKubeV1()
+	case "":
+		return c.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for persistent volume claim: %s", c.Version)
+	}
+}
+
+func (c *PersistentVolumeClaim) toKubeV1() (*v1.PersistentVolumeClaim, error) {
+	spec, err := c.ClaimSpec.toKubeV1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClaim := &v1.PersistentVolumeClaim{}
+	kubeClaim.Name = c.Name
+	kubeClaim.Namespace = c.Namespace
+	kubeClaim.APIVersion = "v1"
+	kubeClaim.ClusterName = c.Cluster
+	kubeClaim.Kind = "PersistentVolumeClaim"
+	kubeClaim.Labels = c.Labels
+	kubeClaim.Annotations = c.Annotations
+	kubeClaim.Spec = *spec
+
+	return kubeClaim, nil
+}
+
+// ToKube will return a kubernetes persistent volume claim object of the
+// provided api version
+func (t *ClaimTemplate) ToKube(version string) (interface{}, error) {
+	switch strings.ToLower(version) {
+	case "v1":
+		return t.toKubeV1()
+	case "":
+		return t.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for claim template: %s", version)
+	}
+}
+
+func (t *ClaimTemplate) toKubeV1() (*v1.PersistentVolumeClaim, error) {
+	spec, err := t.ClaimSpec.toKubeV1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClaim := &v1.PersistentVolumeClaim{}
+	kubeClaim.Name = t.Name
+	kubeClaim.Labels = t.Labels
+	kubeClaim.Annotations = t.Annotations
+	kubeClaim.Spec = *spec
+
+	return kubeClaim, nil
+}
+
+// ToKube will return a kubernetes persistent volume claim spec object of
+// the provided api version
+func (s *ClaimSpec) ToKube(version string) (interface{}, error) {
+	switch strings.ToLower(version) {
+	case "v1":
+		return s.toKubeV1()
+	case "":
+		return s.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for claim spec: %s", version)
+	}
+}
+
+func (s *ClaimSpec) toKubeV1() (*v1.PersistentVolumeClaimSpec, error) {
+	accessModes, err := s.AccessModes.ToKube()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.accessModes")
+	}
+
+	volumeMode, err := s.VolumeMode.ToKube()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.volumeMode")
+	}
+
+	kubeSpec := &v1.PersistentVolumeClaimSpec{
+		AccessModes:      accessModes,
+		Selector:         s.Selector.ToKubeLabelSelectorV1(),
+		VolumeName:       s.VolumeName,
+		StorageClassName: s.StorageClass,
+		VolumeMode:       volumeMode,
+	}
+	if s.Size != nil {
+		kubeSpec.Resources.Requests = v1.ResourceList{v1.ResourceStorage: *s.Size}
+	}
+	if s.Limit != nil {
+		kubeSpec.Resources.Limits = v1.ResourceList{v1.ResourceStorage: *s.Limit}
+	}
+
+	return kubeSpec, nil
+}
+
+// ToKube converts the access modes for a kubernetes persistent volume or
+// claim
+func (m AccessModes) ToKube() ([]v1.PersistentVolumeAccessMode, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	kubeModes := make([]v1.PersistentVolumeAccessMode, len(m))
+	for i, mode := range m {
+		switch mode {
+		case AccessModeReadWriteOnce:
+			kubeModes[i] = v1.ReadWriteOnce
+		case AccessModeReadOnlyMany:
+			kubeModes[i] = v1.ReadOnlyMany
+		case AccessModeReadWriteMany:
+			kubeModes[i] = v1.ReadWriteMany
+		default:
+			return nil, serrors.InvalidValueErrorf(mode, "unrecognized access mode")
+		}
+	}
+
+	return kubeModes, nil
+}
+
+// ToKube converts the volume mode for a kubernetes persistent volume or
+// claim
+func (m VolumeMode) ToKube() (*v1.PersistentVolumeMode, error) {
+	var mode v1.PersistentVolumeMode
+	switch m {
+	case VolumeModeUnset:
+		return nil, nil
+	case VolumeModeBlock:
+		mode = v1.PersistentVolumeBlock
+	case VolumeModeFilesystem:
+		mode = v1.PersistentVolumeFilesystem
+	default:
+		return nil, serrors.InvalidValueErrorf(m, "unrecognized volume mode")
+	}
+
+	return &mode, nil
+}
@@ -0,0 +1,125 @@
+package rbac
+
+import (
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// NewRulesFromKubePolicyRulesV1 converts the rules of a kubernetes role or
+// cluster role.  A kubernetes rule applies to every resource in every api
+// group it lists, so each combination is written as a resource.
+func NewRulesFromKubePolicyRulesV1(kubeRules []rbacv1.PolicyRule) ([]Rule, error) {
+	var rules []Rule
+	for i := range kubeRules {
+		rule, err := fromKubePolicyRuleV1(&kubeRules[i])
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+func fromKubePolicyRuleV1(kubeRule *rbacv1.PolicyRule) (*Rule, error) {
+	rule := &Rule{
+		Verbs: kubeRule.Verbs,
+		Names: kubeRule.ResourceNames,
+	}
+
+	if len(kubeRule.NonResourceURLs) > 0 {
+		if len(kubeRule.APIGroups) > 0 || len(kubeRule.Resources) > 0 {
+			return nil, serrors.InvalidInstanceErrorf(kubeRule, "a rule can't apply to both resources and non-resource urls")
+		}
+		for _, url := range kubeRule.NonResourceURLs {
+			if !isNonResourceURL(url) {
+				return nil, serrors.InvalidValueErrorf(url, "non-resource urls must start with /")
+			}
+		}
+		rule.Resources = kubeRule.NonResourceURLs
+		return rule, nil
+	}
+
+	if (len(kubeRule.APIGroups) == 0) != (len(kubeRule.Resources) == 0) {
+		return nil, serrors.InvalidInstanceErrorf(kubeRule, "a rule must list both api groups and resources")
+	}
+
+	for _, group := range kubeRule.APIGroups {
+		if strings.Contains(group, groupSeparator) || group == coreGroupAlias {
+			return nil, serrors.InvalidValueErrorf(group, "unsupported api group")
+		}
+		for _, resource := range kubeRule.Resources {
+			if isNonResourceURL(resource) || strings.Count(resource, groupSeparator) > 1 {
+				return nil, serrors.InvalidValueErrorf(resource, "unsupported resource")
+			}
+			rule.Resources = append(rule.Resources, joinResource(group, resource))
+		}
+	}
+
+	return rule, nil
+}
+
+// NewSubjectsFromKubeSubjectsV1 converts the subjects of a kubernetes role
+// binding or cluster role binding
+func NewSubjectsFromKubeSubjectsV1(kubeSubjects []rbacv1.Subject) ([]Subject, error) {
+	var subjects []Subject
+	for i, kubeSubject := range kubeSubjects {
+		subject, err := fromKubeSubjectV1(kubeSubject)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		subjects = append(subjects, *subject)
+	}
+
+	return subjects, nil
+}
+
+func fromKubeSubjectV1(kubeSubject rbacv1.Subject) (*Subject, error) {
+	subject := &Subject{
+		Name:      kubeSubject.Name,
+		Namespace: kubeSubject.Namespace,
+	}
+
+	// The api group is defaulted by the api server, so it may be left out
+	expectedGroup := rbacv1.GroupName
+	switch kubeSubject.Kind {
+	case rbacv1.UserKind:
+		subject.Kind = SubjectKindUser
+	case rbacv1.GroupKind:
+		subject.Kind = SubjectKindGroup
+	case rbacv1.ServiceAccountKind:
+		subject.Kind = SubjectKindServiceAccount
+		expectedGroup = ""
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeSubject.Kind, "unrecognized subject kind")
+	}
+
+	if len(kubeSubject.APIGroup) > 0 && kubeSubject.APIGroup != expectedGroup {
+		return nil, serrors.InvalidValueErrorf(kubeSubject.APIGroup, "unexpected api group for %s", kubeSubject.Kind)
+	}
+	if len(subject.Namespace) > 0 && subject.Kind != SubjectKindServiceAccount {
+		return nil, serrors.InvalidInstanceErrorf(kubeSubject, "only service accounts have a namespace")
+	}
+
+	return subject, nil
+}
+
+// NewRoleRefFromKubeRoleRefV1 converts the role granted by a kubernetes
+// role binding or cluster role binding
+func NewRoleRefFromKubeRoleRefV1(kubeRef rbacv1.RoleRef) (*RoleRef, error) {
+	if len(kubeRef.APIGroup) > 0 && kubeRef.APIGroup != rbacv1.GroupName {
+		return nil, serrors.InvalidValueErrorf(kubeRef.APIGroup, "unexpected api group for the role")
+	}
+
+	switch kubeRef.Kind {
+	case "Role":
+		return &RoleRef{Kind: RoleKindRole, Name: kubeRef.Name}, nil
+	case "ClusterRole":
+		return &RoleRef{Kind: RoleKindClusterRole, Name: kubeRef.Name}, nil
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeRef.Kind, "unrecognized role kind")
+	}
+}
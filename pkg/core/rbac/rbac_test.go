@@ -0,0 +1,152 @@
+package rbac
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestRuleRoundTrip(t *testing.T) {
+	testcases := []struct {
+		description string
+		kubeRules   []rbacv1.PolicyRule
+		rules       string
+	}{
+		{
+			description: "core and named groups",
+			kubeRules: []rbacv1.PolicyRule{
+				{Verbs: []string{"get", "list"}, APIGroups: []string{"", "apps"}, Resources: []string{"deployments"}},
+			},
+			rules: `["get,list: deployments,apps/deployments"]`,
+		},
+		{
+			description: "subresources",
+			kubeRules: []rbacv1.PolicyRule{
+				{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods/log"}},
+				{Verbs: []string{"update"}, APIGroups: []string{"apps"}, Resources: []string{"deployments/scale"}},
+			},
+			rules: `["get: core/pods/log","update: apps/deployments/scale"]`,
+		},
+		{
+			description: "resource names",
+			kubeRules: []rbacv1.PolicyRule{
+				{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"app-config"}},
+			},
+			rules: `[{"verbs":"get","resources":"configmaps","names":"app-config"}]`,
+		},
+		{
+			description: "non-resource urls",
+			kubeRules: []rbacv1.PolicyRule{
+				{Verbs: []string{"get"}, NonResourceURLs: []string{"/healthz", "/api/*"}},
+			},
+			rules: `["get: /healthz,/api/*"]`,
+		},
+	}
+
+	for _, tc := range testcases {
+		rules, err := NewRulesFromKubePolicyRulesV1(tc.kubeRules)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", tc.description, err)
+		}
+
+		data, err := json.Marshal(rules)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", tc.description, err)
+		}
+		if string(data) != tc.rules {
+			t.Errorf("%s: expected %s, got %s", tc.description, tc.rules, data)
+		}
+
+		rules = nil
+		if err := json.Unmarshal(data, &rules); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", tc.description, data, err)
+		}
+		kubeRules, err := NewKubePolicyRulesV1(rules)
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", tc.description, err)
+		}
+		if !reflect.DeepEqual(kubeRules, tc.kubeRules) {
+			t.Errorf("%s: round trip changed the rules\nexpected %#v\ngot      %#v", tc.description, tc.kubeRules, kubeRules)
+		}
+	}
+}
+
+func TestRuleSplitByGroup(t *testing.T) {
+	rule := Rule{}
+	if err := json.Unmarshal([]byte(`"get,list,watch: apps/deployments,pods"`), &rule); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	kubeRules, err := NewKubePolicyRulesV1([]Rule{rule})
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+
+	expected := []rbacv1.PolicyRule{
+		{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+		{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+	}
+	if !reflect.DeepEqual(kubeRules, expected) {
+		t.Errorf("expected %#v, got %#v", expected, kubeRules)
+	}
+}
+
+func TestSubjectShorthand(t *testing.T) {
+	testcases := []struct {
+		str     string
+		subject Subject
+		pass    bool
+	}{
+		{str: "user:alice", subject: Subject{Kind: SubjectKindUser, Name: "alice"}, pass: true},
+		{str: "group:system:masters", subject: Subject{Kind: SubjectKindGroup, Name: "system:masters"}, pass: true},
+		{str: "sa:kube-system/dns", subject: Subject{Kind: SubjectKindServiceAccount, Namespace: "kube-system", Name: "dns"}, pass: true},
+		{str: "sa:builder", subject: Subject{Kind: SubjectKindServiceAccount, Name: "builder"}, pass: true},
+		{str: "robot:r2d2", pass: false},
+		{str: "user:", pass: false},
+	}
+
+	for _, tc := range testcases {
+		subject := Subject{}
+		err := json.Unmarshal([]byte(`"`+tc.str+`"`), &subject)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+			continue
+		}
+		if !tc.pass {
+			continue
+		}
+		if subject != tc.subject {
+			t.Errorf("%s: expected %#v, got %#v", tc.str, tc.subject, subject)
+		}
+
+		data, err := json.Marshal(subject)
+		if err != nil {
+			t.Errorf("%s: marshal failed: %v", tc.str, err)
+		} else if string(data) != `"`+tc.str+`"` {
+			t.Errorf("%s: round trip produced %s", tc.str, data)
+		}
+	}
+}
+
+func TestRoleRefShorthand(t *testing.T) {
+	ref := RoleRef{}
+	if err := json.Unmarshal([]byte(`"cluster-role:admin"`), &ref); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	kubeRef, err := NewKubeRoleRefV1(ref)
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	expected := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"}
+	if kubeRef != expected {
+		t.Errorf("expected %#v, got %#v", expected, kubeRef)
+	}
+
+	if err := json.Unmarshal([]byte(`"policy:admin"`), &ref); err == nil {
+		t.Errorf("expected an unknown role kind to be rejected")
+	}
+}
@@ -0,0 +1,141 @@
+package rbac
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+const (
+	ruleSeparator  = ":"
+	listSeparator  = ","
+	groupSeparator = "/"
+
+	// coreGroupAlias names the core api group in resources that have a
+	// subresource, e.g. "core/pods/log", where the empty group name
+	// would be ambiguous
+	coreGroupAlias = "core"
+)
+
+// Rule grants verbs on resources.  It is written as "verbs: resources",
+// e.g. "get,list,watch: apps/deployments,pods".  Resources are written as
+// "group/resource" or, for the core api group, just "resource".  A
+// subresource follows the resource, e.g. "apps/deployments/scale", and
+// the core group is then named "core", e.g. "core/pods/log".  Resources
+// starting with "/" are non-resource urls, e.g. "get: /healthz".
+//
+// Rules that only apply to some objects by name are written as a
+// dictionary, e.g. {verbs: get, resources: configmaps, names: app-config}.
+type Rule struct {
+	Verbs     []string
+	Resources []string
+	Names     []string
+}
+
+// ruleDict is the dictionary form of a Rule, every field is a comma
+// separated list
+type ruleDict struct {
+	Verbs     string `json:"verbs"`
+	Resources string `json:"resources,omitempty"`
+	Names     string `json:"names,omitempty"`
+}
+
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		return r.Unmarshal(str)
+	}
+
+	obj := ruleDict{}
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected either a rule string or dictionary")
+	}
+
+	r.Verbs = splitList(obj.Verbs)
+	r.Resources = splitList(obj.Resources)
+	r.Names = splitList(obj.Names)
+	return nil
+}
+
+// Unmarshal reads the "verbs: resources" shorthand
+func (r *Rule) Unmarshal(str string) error {
+	segments := strings.SplitN(str, ruleSeparator, 2)
+	if len(segments) != 2 {
+		return serrors.InvalidValueErrorf(str, "expected verbs: resources")
+	}
+
+	r.Verbs = splitList(segments[0])
+	r.Resources = splitList(segments[1])
+	r.Names = nil
+	return nil
+}
+
+func (r Rule) MarshalJSON() ([]byte, error) {
+	if len(r.Names) == 0 {
+		return json.Marshal(r.Marshal())
+	}
+
+	return json.Marshal(ruleDict{
+		Verbs:     strings.Join(r.Verbs, listSeparator),
+		Resources: strings.Join(r.Resources, listSeparator),
+		Names:     strings.Join(r.Names, listSeparator),
+	})
+}
+
+// Marshal returns the "verbs: resources" shorthand, which leaves out the
+// names of the rule
+func (r Rule) Marshal() string {
+	return strings.Join(r.Verbs, listSeparator) + ruleSeparator + " " + strings.Join(r.Resources, listSeparator)
+}
+
+func splitList(str string) []string {
+	list := []string{}
+	for _, item := range strings.Split(str, listSeparator) {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			list = append(list, item)
+		}
+	}
+
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+func isNonResourceURL(resource string) bool {
+	return strings.HasPrefix(resource, "/")
+}
+
+// splitResource returns the api group and the resource, including any
+// subresource, of a resource written in a rule
+func splitResource(resource string) (string, string, error) {
+	segments := strings.Split(resource, groupSeparator)
+	switch len(segments) {
+	case 1:
+		return "", resource, nil
+	case 2, 3:
+		group := segments[0]
+		if group == coreGroupAlias {
+			group = ""
+		}
+		return group, strings.Join(segments[1:], groupSeparator), nil
+	default:
+		return "", "", serrors.InvalidValueErrorf(resource, "expected [group/]resource[/subresource]")
+	}
+}
+
+// joinResource writes the api group and the resource, including any
+// subresource, as a resource of a rule
+func joinResource(group, resource string) string {
+	if len(group) == 0 {
+		if !strings.Contains(resource, groupSeparator) {
+			return resource
+		}
+		group = coreGroupAlias
+	}
+
+	return group + groupSeparator + resource
+}
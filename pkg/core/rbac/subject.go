@@ -0,0 +1,131 @@
+package rbac
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+type SubjectKind string
+
+const (
+	SubjectKindUser           SubjectKind = "user"
+	SubjectKindGroup          SubjectKind = "group"
+	SubjectKindServiceAccount SubjectKind = "sa"
+)
+
+const (
+	subjectSeparator   = ":"
+	namespaceSeparator = "/"
+)
+
+// Subject is a user, group or service account that a role is bound to.
+// It is written as "kind:name", e.g. "user:alice" or "group:system:masters".
+// Service accounts are written as "sa:namespace/name", or "sa:name" for a
+// service account in the namespace of the binding.
+type Subject struct {
+	Kind      SubjectKind
+	Name      string
+	Namespace string
+}
+
+func (s *Subject) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a subject string")
+	}
+
+	return s.Unmarshal(str)
+}
+
+// Unmarshal reads the "kind:name" shorthand
+func (s *Subject) Unmarshal(str string) error {
+	segments := strings.SplitN(str, subjectSeparator, 2)
+	if len(segments) != 2 || len(segments[1]) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected kind:name")
+	}
+
+	*s = Subject{
+		Kind: SubjectKind(segments[0]),
+		Name: segments[1],
+	}
+	switch s.Kind {
+	case SubjectKindUser, SubjectKindGroup:
+	case SubjectKindServiceAccount:
+		if i := strings.Index(s.Name, namespaceSeparator); i >= 0 {
+			s.Namespace, s.Name = s.Name[:i], s.Name[i+1:]
+		}
+	default:
+		return serrors.InvalidValueErrorf(str, "unrecognized subject kind, expected %s, %s or %s", SubjectKindUser, SubjectKindGroup, SubjectKindServiceAccount)
+	}
+
+	return nil
+}
+
+func (s Subject) MarshalJSON() ([]byte, error) {
+	str, err := s.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(str)
+}
+
+// Marshal returns the "kind:name" shorthand for the subject
+func (s Subject) Marshal() (string, error) {
+	name := s.Name
+	if len(s.Namespace) > 0 {
+		if s.Kind != SubjectKindServiceAccount {
+			return "", serrors.InvalidInstanceErrorf(s, "only service accounts have a namespace")
+		}
+		name = s.Namespace + namespaceSeparator + name
+	}
+
+	return string(s.Kind) + subjectSeparator + name, nil
+}
+
+type RoleKind string
+
+const (
+	RoleKindRole        RoleKind = "role"
+	RoleKindClusterRole RoleKind = "cluster-role"
+)
+
+// RoleRef is the role granted by a binding.  It is written as "kind:name",
+// e.g. "cluster-role:admin" or "role:pod-reader".
+type RoleRef struct {
+	Kind RoleKind
+	Name string
+}
+
+func (r *RoleRef) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a role reference string")
+	}
+
+	return r.Unmarshal(str)
+}
+
+// Unmarshal reads the "kind:name" shorthand
+func (r *RoleRef) Unmarshal(str string) error {
+	segments := strings.SplitN(str, subjectSeparator, 2)
+	if len(segments) != 2 || len(segments[1]) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected kind:name")
+	}
+
+	switch kind := RoleKind(segments[0]); kind {
+	case RoleKindRole, RoleKindClusterRole:
+		*r = RoleRef{Kind: kind, Name: segments[1]}
+		return nil
+	default:
+		return serrors.InvalidValueErrorf(str, "unrecognized role kind, expected %s or %s", RoleKindRole, RoleKindClusterRole)
+	}
+}
+
+func (r RoleRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r.Kind) + subjectSeparator + r.Name)
+}
@@ -0,0 +1,163 @@
+package rbac
+
+import (
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+)
+
+// NewKubePolicyRulesV1 converts rules for a kubernetes role or cluster
+// role.  A rule becomes a single kubernetes rule if it lists every
+// combination of its api groups and resources, otherwise it becomes one
+// kubernetes rule per api group.
+func NewKubePolicyRulesV1(rules []Rule) ([]rbacv1.PolicyRule, error) {
+	var kubeRules []rbacv1.PolicyRule
+	for i := range rules {
+		converted, err := rules[i].toKubeV1()
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		kubeRules = append(kubeRules, converted...)
+	}
+
+	return kubeRules, nil
+}
+
+func (r *Rule) toKubeV1() ([]rbacv1.PolicyRule, error) {
+	urls := []string{}
+	groups := []string{}
+	resources := map[string][]string{}
+	allResources := []string{}
+	pairs := map[[2]string]bool{}
+	for _, res := range r.Resources {
+		if isNonResourceURL(res) {
+			urls = append(urls, res)
+			continue
+		}
+
+		group, resource, err := splitResource(res)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := resources[group]; !ok {
+			groups = append(groups, group)
+		}
+		if !contains(allResources, resource) {
+			allResources = append(allResources, resource)
+		}
+		if !pairs[[2]string{group, resource}] {
+			resources[group] = append(resources[group], resource)
+			pairs[[2]string{group, resource}] = true
+		}
+	}
+
+	if len(urls) > 0 {
+		if len(groups) > 0 {
+			return nil, serrors.InvalidInstanceErrorf(r, "a rule can't apply to both resources and non-resource urls")
+		}
+		return []rbacv1.PolicyRule{{
+			Verbs:           r.Verbs,
+			NonResourceURLs: urls,
+			ResourceNames:   r.Names,
+		}}, nil
+	}
+
+	if len(pairs) == len(groups)*len(allResources) {
+		return []rbacv1.PolicyRule{{
+			Verbs:         r.Verbs,
+			APIGroups:     nilIfEmpty(groups),
+			Resources:     nilIfEmpty(allResources),
+			ResourceNames: r.Names,
+		}}, nil
+	}
+
+	kubeRules := []rbacv1.PolicyRule{}
+	for _, group := range groups {
+		kubeRules = append(kubeRules, rbacv1.PolicyRule{
+			Verbs:         r.Verbs,
+			APIGroups:     []string{group},
+			Resources:     resources[group],
+			ResourceNames: r.Names,
+		})
+	}
+
+	return kubeRules, nil
+}
+
+// NewKubeSubjectsV1 converts subjects for a kubernetes role binding or
+// cluster role binding
+func NewKubeSubjectsV1(subjects []Subject) ([]rbacv1.Subject, error) {
+	var kubeSubjects []rbacv1.Subject
+	for i, subject := range subjects {
+		kubeSubject := rbacv1.Subject{
+			Name:      subject.Name,
+			Namespace: subject.Namespace,
+		}
+
+		switch subject.Kind {
+		case SubjectKindUser:
+			kubeSubject.Kind = rbacv1.UserKind
+			kubeSubject.APIGroup = rbacv1.GroupName
+		case SubjectKindGroup:
+			kubeSubject.Kind = rbacv1.GroupKind
+			kubeSubject.APIGroup = rbacv1.GroupName
+		case SubjectKindServiceAccount:
+			kubeSubject.Kind = rbacv1.ServiceAccountKind
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(subject.Kind, "unrecognized subject kind"), "$.%d", i)
+		}
+
+		kubeSubjects = append(kubeSubjects, kubeSubject)
+	}
+
+	return kubeSubjects, nil
+}
+
+// SetKubeSubjectsAPIVersionV1alpha1 fills in the api version of subjects
+// converted to rbac v1alpha1, which names the api version of a subject
+// instead of its api group
+func SetKubeSubjectsAPIVersionV1alpha1(kubeSubjects []rbacv1alpha1.Subject) {
+	for i := range kubeSubjects {
+		switch kubeSubjects[i].Kind {
+		case rbacv1alpha1.UserKind, rbacv1alpha1.GroupKind:
+			kubeSubjects[i].APIVersion = rbacv1alpha1.SchemeGroupVersion.String()
+		}
+	}
+}
+
+// NewKubeRoleRefV1 converts the role granted by a kubernetes role binding
+// or cluster role binding
+func NewKubeRoleRefV1(ref RoleRef) (rbacv1.RoleRef, error) {
+	kubeRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Name:     ref.Name,
+	}
+
+	switch ref.Kind {
+	case RoleKindRole:
+		kubeRef.Kind = "Role"
+	case RoleKindClusterRole:
+		kubeRef.Kind = "ClusterRole"
+	default:
+		return kubeRef, serrors.InvalidValueErrorf(ref.Kind, "unrecognized role kind")
+	}
+
+	return kubeRef, nil
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func nilIfEmpty(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
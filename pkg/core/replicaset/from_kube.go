@@ -0,0 +1,74 @@
+package replicaset
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+)
+
+// NewReplicaSetFromKubeReplicaSet will create a new ReplicaSet object with
+// the data from a provided kubernetes replica set object of any supported
+// api version
+func NewReplicaSetFromKubeReplicaSet(obj interface{}) (*ReplicaSet, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(appsv1.ReplicaSet{}):
+		o := obj.(appsv1.ReplicaSet)
+		return fromKubeReplicaSetV1(&o)
+	case reflect.TypeOf(&appsv1.ReplicaSet{}):
+		return fromKubeReplicaSetV1(obj.(*appsv1.ReplicaSet))
+	case reflect.TypeOf(appsv1beta2.ReplicaSet{}):
+		o := obj.(appsv1beta2.ReplicaSet)
+		return fromKubeReplicaSetViaV1(&o)
+	case reflect.TypeOf(&appsv1beta2.ReplicaSet{}):
+		return fromKubeReplicaSetViaV1(obj.(*appsv1beta2.ReplicaSet))
+	case reflect.TypeOf(extensionsv1beta1.ReplicaSet{}):
+		o := obj.(extensionsv1beta1.ReplicaSet)
+		return fromKubeReplicaSetViaV1(&o)
+	case reflect.TypeOf(&extensionsv1beta1.ReplicaSet{}):
+		return fromKubeReplicaSetViaV1(obj.(*extensionsv1beta1.ReplicaSet))
+	default:
+		return nil, fmt.Errorf("unknown ReplicaSet version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeReplicaSetV1(kubeReplicaSet *appsv1.ReplicaSet) (*ReplicaSet, error) {
+	template, err := pod.NewPodTemplateFromKubePodTemplateSpec(&kubeReplicaSet.Spec.Template)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.template")
+	}
+
+	return &ReplicaSet{
+		Name:        kubeReplicaSet.Name,
+		Namespace:   kubeReplicaSet.Namespace,
+		Version:     kubeReplicaSet.APIVersion,
+		Cluster:     kubeReplicaSet.ClusterName,
+		Labels:      kubeReplicaSet.Labels,
+		Annotations: kubeReplicaSet.Annotations,
+		Replicas:    kubeReplicaSet.Spec.Replicas,
+		Selector:    selector.NewLabelSelectorFromKubeLabelSelectorV1(kubeReplicaSet.Spec.Selector),
+		MinReady:    kubeReplicaSet.Spec.MinReadySeconds,
+		Template:    *template,
+	}, nil
+}
+
+// fromKubeReplicaSetViaV1 converts a replica set of an older api version,
+// which has the same fields as apps/v1.  The api version of the original
+// is kept.
+func fromKubeReplicaSetViaV1(kubeReplicaSet interface{}) (*ReplicaSet, error) {
+	v1ReplicaSet := &appsv1.ReplicaSet{}
+	err := converterutils.ConvertKubeObject(kubeReplicaSet, v1ReplicaSet)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeReplicaSet, "couldn't convert to %s: %s", appsv1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeReplicaSetV1(v1ReplicaSet)
+}
@@ -0,0 +1,34 @@
+package replicaset
+
+import (
+	"mantle/pkg/registry"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "replica_set"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			appsv1.SchemeGroupVersion.WithKind("ReplicaSet"),
+			appsv1beta2.SchemeGroupVersion.WithKind("ReplicaSet"),
+			extensionsv1beta1.SchemeGroupVersion.WithKind("ReplicaSet"),
+		},
+		New: func() registry.Object {
+			return &ReplicaSet{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			r, err := NewReplicaSetFromKubeReplicaSet(obj)
+			if err != nil {
+				return nil, err
+			}
+			return r, nil
+		},
+	})
+}
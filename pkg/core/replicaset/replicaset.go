@@ -0,0 +1,22 @@
+package replicaset
+
+import (
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+)
+
+// ReplicaSet defines a replica set object
+type ReplicaSet struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Replicas *int32                  `json:"replicas,omitempty"`
+	Selector *selector.LabelSelector `json:"selector,omitempty"`
+	MinReady int32                   `json:"minReady,omitempty"`
+
+	Template pod.PodTemplate `json:"template"`
+}
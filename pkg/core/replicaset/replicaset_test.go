@@ -0,0 +1,105 @@
+package replicaset
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/internal/converterutils"
+
+	"github.com/koki/json"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func testKubeReplicaSet() *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "testNS",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: int32Ptr(2),
+			Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"web"}},
+				},
+			},
+			MinReadySeconds: 10,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "web"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "nginx", Image: "nginx"}},
+				},
+			},
+		},
+	}
+}
+
+func TestReplicaSetRoundTrip(t *testing.T) {
+	testcases := []struct {
+		version string
+		obj     runtime.Object
+	}{
+		{
+			version: "apps/v1",
+			obj:     &appsv1.ReplicaSet{},
+		},
+		{
+			version: "apps/v1beta2",
+			obj:     &appsv1beta2.ReplicaSet{},
+		},
+		{
+			version: "extensions/v1beta1",
+			obj:     &extensionsv1beta1.ReplicaSet{},
+		},
+	}
+
+	for _, tc := range testcases {
+		err := converterutils.ConvertKubeObject(testKubeReplicaSet(), tc.obj)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.version, err)
+		}
+		tc.obj.GetObjectKind().SetGroupVersionKind(schema.FromAPIVersionAndKind(tc.version, "ReplicaSet"))
+
+		r, err := NewReplicaSetFromKubeReplicaSet(tc.obj)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", tc.version, err)
+		}
+		if r.Version != tc.version {
+			t.Errorf("%s: wrong version %s", tc.version, r.Version)
+		}
+
+		data, err := json.Marshal(r)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", tc.version, err)
+		}
+		r = &ReplicaSet{}
+		if err := json.Unmarshal(data, r); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", tc.version, data, err)
+		}
+
+		obj, err := r.ToKube()
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", tc.version, err)
+		}
+		if !reflect.DeepEqual(obj, tc.obj) {
+			t.Errorf("%s: round trip changed the replica set\nexpected %#v\ngot      %#v", tc.version, tc.obj, obj)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package replicaset
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes replica set object of the api version
+// type defined in the object
+func (r *ReplicaSet) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(r.Version) {
+	case "apps/v1":
+		return r.toKubeV1()
+	case "":
+		return r.toKubeV1()
+	case "apps/v1beta2":
+		return r.toKubeV1beta2()
+	case "extensions/v1beta1":
+		return r.toKubeExtensionsV1beta1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for replica set: %s", r.Version)
+	}
+}
+
+func (r *ReplicaSet) toKubeV1() (*appsv1.ReplicaSet, error) {
+	template, err := r.Template.ToKube("v1")
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.template")
+	}
+
+	kubeReplicaSet := &appsv1.ReplicaSet{}
+	kubeReplicaSet.Name = r.Name
+	kubeReplicaSet.Namespace = r.Namespace
+	kubeReplicaSet.APIVersion = "apps/v1"
+	kubeReplicaSet.ClusterName = r.Cluster
+	kubeReplicaSet.Kind = "ReplicaSet"
+	kubeReplicaSet.Labels = r.Labels
+	kubeReplicaSet.Annotations = r.Annotations
+	kubeReplicaSet.Spec = appsv1.ReplicaSetSpec{
+		Replicas:        r.Replicas,
+		Selector:        r.Selector.ToKubeLabelSelectorV1(),
+		MinReadySeconds: r.MinReady,
+		Template:        *template.(*v1.PodTemplateSpec),
+	}
+
+	return kubeReplicaSet, nil
+}
+
+func (r *ReplicaSet) toKubeV1beta2() (*appsv1beta2.ReplicaSet, error) {
+	kubeReplicaSet := &appsv1beta2.ReplicaSet{}
+	err := r.toKubeViaV1(kubeReplicaSet)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeReplicaSet.SetGroupVersionKind(appsv1beta2.SchemeGroupVersion.WithKind("ReplicaSet"))
+	return kubeReplicaSet, nil
+}
+
+func (r *ReplicaSet) toKubeExtensionsV1beta1() (*extensionsv1beta1.ReplicaSet, error) {
+	kubeReplicaSet := &extensionsv1beta1.ReplicaSet{}
+	err := r.toKubeViaV1(kubeReplicaSet)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeReplicaSet.SetGroupVersionKind(extensionsv1beta1.SchemeGroupVersion.WithKind("ReplicaSet"))
+	return kubeReplicaSet, nil
+}
+
+// toKubeViaV1 converts the replica set to apps/v1 and from there into
+// kubeReplicaSet, a replica set of an older api version
+func (r *ReplicaSet) toKubeViaV1(kubeReplicaSet interface{}) error {
+	v1ReplicaSet, err := r.toKubeV1()
+	if err != nil {
+		return err
+	}
+
+	err = converterutils.ConvertKubeObject(v1ReplicaSet, kubeReplicaSet)
+	if err != nil {
+		return serrors.InvalidInstanceErrorf(r, "couldn't convert to %s: %s", r.Version, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package replicationcontroller
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+)
+
+// NewReplicationControllerFromKubeReplicationController will create a new
+// ReplicationController object with the data from a provided kubernetes
+// replication controller object
+func NewReplicationControllerFromKubeReplicationController(obj interface{}) (*ReplicationController, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.ReplicationController{}):
+		o := obj.(v1.ReplicationController)
+		return fromKubeReplicationControllerV1(&o)
+	case reflect.TypeOf(&v1.ReplicationController{}):
+		return fromKubeReplicationControllerV1(obj.(*v1.ReplicationController))
+	default:
+		return nil, fmt.Errorf("unknown ReplicationController version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeReplicationControllerV1(kubeRC *v1.ReplicationController) (*ReplicationController, error) {
+	rc := &ReplicationController{
+		Name:        kubeRC.Name,
+		Namespace:   kubeRC.Namespace,
+		Version:     kubeRC.APIVersion,
+		Cluster:     kubeRC.ClusterName,
+		Labels:      kubeRC.Labels,
+		Annotations: kubeRC.Annotations,
+		Replicas:    kubeRC.Spec.Replicas,
+		MinReady:    kubeRC.Spec.MinReadySeconds,
+	}
+
+	if len(kubeRC.Spec.Selector) > 0 {
+		rc.Selector = &selector.LabelSelector{
+			MatchLabels: kubeRC.Spec.Selector,
+		}
+	}
+
+	if kubeRC.Spec.Template != nil {
+		template, err := pod.NewPodTemplateFromKubePodTemplateSpec(kubeRC.Spec.Template)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.spec.template")
+		}
+		rc.Template = template
+	}
+
+	return rc, nil
+}
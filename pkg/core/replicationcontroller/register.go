@@ -0,0 +1,30 @@
+package replicationcontroller
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "replication_controller"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("ReplicationController"),
+		},
+		New: func() registry.Object {
+			return &ReplicationController{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			rc, err := NewReplicationControllerFromKubeReplicationController(obj)
+			if err != nil {
+				return nil, err
+			}
+			return rc, nil
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package replicationcontroller
+
+import (
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+)
+
+// ReplicationController defines a replication controller object
+type ReplicationController struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Selector can only match labels exactly, set based requirements
+	// aren't supported by replication controllers
+	Selector *selector.LabelSelector `json:"selector,omitempty"`
+	MinReady int32                   `json:"minReady,omitempty"`
+
+	Template *pod.PodTemplate `json:"template,omitempty"`
+}
@@ -0,0 +1,74 @@
+package replicationcontroller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestReplicationControllerRoundTrip(t *testing.T) {
+	kubeRC := &v1.ReplicationController{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ReplicationController",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "testNS",
+		},
+		Spec: v1.ReplicationControllerSpec{
+			Replicas:        int32Ptr(2),
+			MinReadySeconds: 10,
+			Selector:        map[string]string{"app": "web"},
+			Template: &v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "web"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "nginx", Image: "nginx"}},
+				},
+			},
+		},
+	}
+
+	rc, err := NewReplicationControllerFromKubeReplicationController(kubeRC)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(rc)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	rc = &ReplicationController{}
+	if err := json.Unmarshal(data, rc); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := rc.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeRC) {
+		t.Errorf("round trip changed the replication controller\nexpected %#v\ngot      %#v", kubeRC, obj)
+	}
+}
+
+func TestReplicationControllerSelector(t *testing.T) {
+	rc := &ReplicationController{}
+	if err := json.Unmarshal([]byte(`{"selector": "app in (web,api)"}`), rc); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	if _, err := rc.ToKube(); err == nil {
+		t.Errorf("expected set based selector to be rejected")
+	}
+}
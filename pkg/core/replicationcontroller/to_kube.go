@@ -0,0 +1,56 @@
+package replicationcontroller
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes replication controller object of the
+// api version type defined in the object
+func (rc *ReplicationController) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(rc.Version) {
+	case "v1":
+		return rc.toKubeV1()
+	case "":
+		return rc.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for replication controller: %s", rc.Version)
+	}
+}
+
+func (rc *ReplicationController) toKubeV1() (*v1.ReplicationController, error) {
+	kubeRC := &v1.ReplicationController{}
+	kubeRC.Name = rc.Name
+	kubeRC.Namespace = rc.Namespace
+	kubeRC.APIVersion = "v1"
+	kubeRC.ClusterName = rc.Cluster
+	kubeRC.Kind = "ReplicationController"
+	kubeRC.Labels = rc.Labels
+	kubeRC.Annotations = rc.Annotations
+	kubeRC.Spec = v1.ReplicationControllerSpec{
+		Replicas:        rc.Replicas,
+		MinReadySeconds: rc.MinReady,
+	}
+
+	if rc.Selector != nil {
+		if len(rc.Selector.MatchExpressions) > 0 {
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(rc.Selector, "replication controllers only support selecting exact labels"), "$.selector")
+		}
+		kubeRC.Spec.Selector = rc.Selector.MatchLabels
+	}
+
+	if rc.Template != nil {
+		template, err := rc.Template.ToKube("v1")
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.template")
+		}
+		kubeRC.Spec.Template = template.(*v1.PodTemplateSpec)
+	}
+
+	return kubeRC, nil
+}
@@ -0,0 +1,81 @@
+package resourcequota
+
+import (
+	"fmt"
+	"reflect"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NewResourceQuotaFromKubeResourceQuota will create a new ResourceQuota
+// object with the data from a provided kubernetes resource quota object of
+// any supported api version
+func NewResourceQuotaFromKubeResourceQuota(obj interface{}) (*ResourceQuota, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.ResourceQuota{}):
+		o := obj.(v1.ResourceQuota)
+		return fromKubeResourceQuotaV1(&o)
+	case reflect.TypeOf(&v1.ResourceQuota{}):
+		return fromKubeResourceQuotaV1(obj.(*v1.ResourceQuota))
+	default:
+		return nil, fmt.Errorf("unknown ResourceQuota version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeResourceQuotaV1(kubeQuota *v1.ResourceQuota) (*ResourceQuota, error) {
+	scopes, err := fromKubeScopesV1(kubeQuota.Spec.Scopes)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.scopes")
+	}
+
+	return &ResourceQuota{
+		Name:        kubeQuota.Name,
+		Namespace:   kubeQuota.Namespace,
+		Version:     kubeQuota.APIVersion,
+		Cluster:     kubeQuota.ClusterName,
+		Labels:      kubeQuota.Labels,
+		Annotations: kubeQuota.Annotations,
+		Hard:        fromKubeResourceListV1(kubeQuota.Spec.Hard),
+		Scopes:      scopes,
+	}, nil
+}
+
+func fromKubeResourceListV1(kubeList v1.ResourceList) map[string]resource.Quantity {
+	if len(kubeList) == 0 {
+		return nil
+	}
+
+	quantities := map[string]resource.Quantity{}
+	for name, quantity := range kubeList {
+		quantities[string(name)] = quantity
+	}
+
+	return quantities
+}
+
+func fromKubeScopesV1(kubeScopes []v1.ResourceQuotaScope) ([]Scope, error) {
+	if len(kubeScopes) == 0 {
+		return nil, nil
+	}
+
+	scopes := make([]Scope, len(kubeScopes))
+	for i, kubeScope := range kubeScopes {
+		switch kubeScope {
+		case v1.ResourceQuotaScopeTerminating:
+			scopes[i] = ScopeTerminating
+		case v1.ResourceQuotaScopeNotTerminating:
+			scopes[i] = ScopeNotTerminating
+		case v1.ResourceQuotaScopeBestEffort:
+			scopes[i] = ScopeBestEffort
+		case v1.ResourceQuotaScopeNotBestEffort:
+			scopes[i] = ScopeNotBestEffort
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(kubeScope, "unrecognized quota scope"), "$.%d", i)
+		}
+	}
+
+	return scopes, nil
+}
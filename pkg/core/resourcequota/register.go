@@ -0,0 +1,30 @@
+package resourcequota
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "resource_quota"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("ResourceQuota"),
+		},
+		New: func() registry.Object {
+			return &ResourceQuota{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			q, err := NewResourceQuotaFromKubeResourceQuota(obj)
+			if err != nil {
+				return nil, err
+			}
+			return q, nil
+		},
+	})
+}
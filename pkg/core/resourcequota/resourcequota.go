@@ -0,0 +1,30 @@
+package resourcequota
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceQuota defines a resource quota object.  Hard maps each resource
+// to its limit in the namespace, e.g. "requests.cpu: 10" or "pods: 20".
+// The status of the quota is dropped.
+type ResourceQuota struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Hard   map[string]resource.Quantity `json:"hard,omitempty"`
+	Scopes []Scope                      `json:"scopes,omitempty"`
+}
+
+// Scope restricts the pods that a quota tracks
+type Scope string
+
+const (
+	ScopeTerminating    Scope = "terminating"
+	ScopeNotTerminating Scope = "not-terminating"
+	ScopeBestEffort     Scope = "best-effort"
+	ScopeNotBestEffort  Scope = "not-best-effort"
+)
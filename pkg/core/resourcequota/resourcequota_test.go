@@ -0,0 +1,83 @@
+package resourcequota
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceQuotaRoundTrip(t *testing.T) {
+	kubeQuota := &v1.ResourceQuota{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ResourceQuota",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "compute",
+			Namespace: "testNS",
+		},
+		Spec: v1.ResourceQuotaSpec{
+			Hard: v1.ResourceList{
+				v1.ResourceRequestsCPU:  resource.MustParse("10"),
+				v1.ResourceLimitsMemory: resource.MustParse("20Gi"),
+				v1.ResourcePods:         resource.MustParse("50"),
+			},
+			Scopes: []v1.ResourceQuotaScope{v1.ResourceQuotaScopeNotBestEffort},
+		},
+		Status: v1.ResourceQuotaStatus{
+			Used: v1.ResourceList{v1.ResourcePods: resource.MustParse("3")},
+		},
+	}
+
+	q, err := NewResourceQuotaFromKubeResourceQuota(kubeQuota)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	expected := `{"version":"v1","name":"compute","namespace":"testNS",` +
+		`"hard":{"limits.memory":"20Gi","pods":"50","requests.cpu":"10"},"scopes":["not-best-effort"]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	q = &ResourceQuota{}
+	if err := json.Unmarshal(data, q); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := q.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+
+	kubeQuota.Status = v1.ResourceQuotaStatus{}
+	if !reflect.DeepEqual(obj, kubeQuota) {
+		t.Errorf("round trip changed the quota\nexpected %#v\ngot      %#v", kubeQuota, obj)
+	}
+}
+
+func TestResourceQuotaNumbers(t *testing.T) {
+	q := &ResourceQuota{}
+	if err := json.Unmarshal([]byte(`{"hard":{"requests.cpu":10,"pods":"20"}}`), q); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	cpu := q.Hard["requests.cpu"]
+	if cpu.Cmp(resource.MustParse("10")) != 0 {
+		t.Errorf("expected a cpu quota of 10, got %s", cpu.String())
+	}
+
+	q.Scopes = []Scope{"everything"}
+	if _, err := q.ToKube(); err == nil {
+		t.Errorf("expected an error for an unrecognized scope")
+	}
+}
@@ -0,0 +1,84 @@
+package resourcequota
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes resource quota object of the api version
+// type defined in the object
+func (q *ResourceQuota) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(q.Version) {
+	case "v1":
+		return q.toKubeV1()
+	case "":
+		return q.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for resource quota: %s", q.Version)
+	}
+}
+
+func (q *ResourceQuota) toKubeV1() (*v1.ResourceQuota, error) {
+	scopes, err := toKubeScopesV1(q.Scopes)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.scopes")
+	}
+
+	kubeQuota := &v1.ResourceQuota{}
+	kubeQuota.Name = q.Name
+	kubeQuota.Namespace = q.Namespace
+	kubeQuota.APIVersion = "v1"
+	kubeQuota.ClusterName = q.Cluster
+	kubeQuota.Kind = "ResourceQuota"
+	kubeQuota.Labels = q.Labels
+	kubeQuota.Annotations = q.Annotations
+	kubeQuota.Spec = v1.ResourceQuotaSpec{
+		Hard:   toKubeResourceListV1(q.Hard),
+		Scopes: scopes,
+	}
+
+	return kubeQuota, nil
+}
+
+func toKubeResourceListV1(quantities map[string]resource.Quantity) v1.ResourceList {
+	if len(quantities) == 0 {
+		return nil
+	}
+
+	kubeList := v1.ResourceList{}
+	for name, quantity := range quantities {
+		kubeList[v1.ResourceName(name)] = quantity
+	}
+
+	return kubeList
+}
+
+func toKubeScopesV1(scopes []Scope) ([]v1.ResourceQuotaScope, error) {
+	if len(scopes) == 0 {
+		return nil, nil
+	}
+
+	kubeScopes := make([]v1.ResourceQuotaScope, len(scopes))
+	for i, scope := range scopes {
+		switch scope {
+		case ScopeTerminating:
+			kubeScopes[i] = v1.ResourceQuotaScopeTerminating
+		case ScopeNotTerminating:
+			kubeScopes[i] = v1.ResourceQuotaScopeNotTerminating
+		case ScopeBestEffort:
+			kubeScopes[i] = v1.ResourceQuotaScopeBestEffort
+		case ScopeNotBestEffort:
+			kubeScopes[i] = v1.ResourceQuotaScopeNotBestEffort
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(scope, "unrecognized quota scope"), "$.%d", i)
+		}
+	}
+
+	return kubeScopes, nil
+}
@@ -0,0 +1,68 @@
+package role
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/rbac"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+)
+
+// NewRoleFromKubeRole will create a new Role object with the data from a
+// provided kubernetes role object of any supported api version
+func NewRoleFromKubeRole(obj interface{}) (*Role, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(rbacv1.Role{}):
+		o := obj.(rbacv1.Role)
+		return fromKubeRoleV1(&o)
+	case reflect.TypeOf(&rbacv1.Role{}):
+		return fromKubeRoleV1(obj.(*rbacv1.Role))
+	case reflect.TypeOf(rbacv1beta1.Role{}):
+		o := obj.(rbacv1beta1.Role)
+		return fromKubeRoleViaV1(&o)
+	case reflect.TypeOf(&rbacv1beta1.Role{}):
+		return fromKubeRoleViaV1(obj.(*rbacv1beta1.Role))
+	case reflect.TypeOf(rbacv1alpha1.Role{}):
+		o := obj.(rbacv1alpha1.Role)
+		return fromKubeRoleViaV1(&o)
+	case reflect.TypeOf(&rbacv1alpha1.Role{}):
+		return fromKubeRoleViaV1(obj.(*rbacv1alpha1.Role))
+	default:
+		return nil, fmt.Errorf("unknown Role version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeRoleV1(kubeRole *rbacv1.Role) (*Role, error) {
+	rules, err := rbac.NewRulesFromKubePolicyRulesV1(kubeRole.Rules)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.rules")
+	}
+
+	return &Role{
+		Name:        kubeRole.Name,
+		Namespace:   kubeRole.Namespace,
+		Version:     kubeRole.APIVersion,
+		Cluster:     kubeRole.ClusterName,
+		Labels:      kubeRole.Labels,
+		Annotations: kubeRole.Annotations,
+		Rules:       rules,
+	}, nil
+}
+
+// fromKubeRoleViaV1 converts a role of an older api version, which has the
+// same fields as rbac v1.  The api version of the original is kept.
+func fromKubeRoleViaV1(kubeRole interface{}) (*Role, error) {
+	v1Role := &rbacv1.Role{}
+	err := converterutils.ConvertKubeObject(kubeRole, v1Role)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeRole, "couldn't convert to %s: %s", rbacv1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeRoleV1(v1Role)
+}
@@ -0,0 +1,34 @@
+package role
+
+import (
+	"mantle/pkg/registry"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "role"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			rbacv1.SchemeGroupVersion.WithKind("Role"),
+			rbacv1alpha1.SchemeGroupVersion.WithKind("Role"),
+			rbacv1beta1.SchemeGroupVersion.WithKind("Role"),
+		},
+		New: func() registry.Object {
+			return &Role{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			r, err := NewRoleFromKubeRole(obj)
+			if err != nil {
+				return nil, err
+			}
+			return r, nil
+		},
+	})
+}
@@ -0,0 +1,17 @@
+package role
+
+import (
+	"mantle/pkg/core/rbac"
+)
+
+// Role defines a role object, which grants its rules within a namespace
+type Role struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Rules []rbac.Rule `json:"rules,omitempty"`
+}
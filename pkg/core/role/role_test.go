@@ -0,0 +1,49 @@
+package role
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRoleRoundTrip(t *testing.T) {
+	kubeRole := &rbacv1beta1.Role{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1beta1",
+			Kind:       "Role",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-reader",
+			Namespace: "testNS",
+		},
+		Rules: []rbacv1beta1.PolicyRule{
+			{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}},
+		},
+	}
+
+	r, err := NewRoleFromKubeRole(kubeRole)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	r = &Role{}
+	if err := json.Unmarshal(data, r); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := r.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeRole) {
+		t.Errorf("round trip changed the role\nexpected %#v\ngot      %#v", kubeRole, obj)
+	}
+}
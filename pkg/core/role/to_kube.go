@@ -0,0 +1,90 @@
+package role
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/rbac"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes role object of the api version type
+// defined in the object
+func (r *Role) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(r.Version) {
+	case "rbac.authorization.k8s.io/v1":
+		return r.toKubeV1()
+	case "":
+		return r.toKubeV1()
+	case "rbac.authorization.k8s.io/v1beta1":
+		return r.toKubeV1beta1()
+	case "rbac.authorization.k8s.io/v1alpha1":
+		return r.toKubeV1alpha1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for role: %s", r.Version)
+	}
+}
+
+func (r *Role) toKubeV1() (*rbacv1.Role, error) {
+	rules, err := rbac.NewKubePolicyRulesV1(r.Rules)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.rules")
+	}
+
+	kubeRole := &rbacv1.Role{}
+	kubeRole.Name = r.Name
+	kubeRole.Namespace = r.Namespace
+	kubeRole.APIVersion = "rbac.authorization.k8s.io/v1"
+	kubeRole.ClusterName = r.Cluster
+	kubeRole.Kind = "Role"
+	kubeRole.Labels = r.Labels
+	kubeRole.Annotations = r.Annotations
+	kubeRole.Rules = rules
+
+	return kubeRole, nil
+}
+
+func (r *Role) toKubeV1beta1() (*rbacv1beta1.Role, error) {
+	kubeRole := &rbacv1beta1.Role{}
+	err := r.toKubeViaV1(kubeRole)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeRole.SetGroupVersionKind(rbacv1beta1.SchemeGroupVersion.WithKind("Role"))
+	return kubeRole, nil
+}
+
+func (r *Role) toKubeV1alpha1() (*rbacv1alpha1.Role, error) {
+	kubeRole := &rbacv1alpha1.Role{}
+	err := r.toKubeViaV1(kubeRole)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeRole.SetGroupVersionKind(rbacv1alpha1.SchemeGroupVersion.WithKind("Role"))
+	return kubeRole, nil
+}
+
+// toKubeViaV1 converts the role to rbac v1 and from there into kubeRole, a
+// role of an older api version
+func (r *Role) toKubeViaV1(kubeRole interface{}) error {
+	v1Role, err := r.toKubeV1()
+	if err != nil {
+		return err
+	}
+
+	err = converterutils.ConvertKubeObject(v1Role, kubeRole)
+	if err != nil {
+		return serrors.InvalidInstanceErrorf(r, "couldn't convert to %s: %s", r.Version, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package rolebinding
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/rbac"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+)
+
+// NewRoleBindingFromKubeRoleBinding will create a new RoleBinding object
+// with the data from a provided kubernetes role binding object of any
+// supported api version
+func NewRoleBindingFromKubeRoleBinding(obj interface{}) (*RoleBinding, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(rbacv1.RoleBinding{}):
+		o := obj.(rbacv1.RoleBinding)
+		return fromKubeRoleBindingV1(&o)
+	case reflect.TypeOf(&rbacv1.RoleBinding{}):
+		return fromKubeRoleBindingV1(obj.(*rbacv1.RoleBinding))
+	case reflect.TypeOf(rbacv1beta1.RoleBinding{}):
+		o := obj.(rbacv1beta1.RoleBinding)
+		return fromKubeRoleBindingViaV1(&o)
+	case reflect.TypeOf(&rbacv1beta1.RoleBinding{}):
+		return fromKubeRoleBindingViaV1(obj.(*rbacv1beta1.RoleBinding))
+	case reflect.TypeOf(rbacv1alpha1.RoleBinding{}):
+		o := obj.(rbacv1alpha1.RoleBinding)
+		return fromKubeRoleBindingViaV1(&o)
+	case reflect.TypeOf(&rbacv1alpha1.RoleBinding{}):
+		return fromKubeRoleBindingViaV1(obj.(*rbacv1alpha1.RoleBinding))
+	default:
+		return nil, fmt.Errorf("unknown RoleBinding version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeRoleBindingV1(kubeRoleBinding *rbacv1.RoleBinding) (*RoleBinding, error) {
+	subjects, err := rbac.NewSubjectsFromKubeSubjectsV1(kubeRoleBinding.Subjects)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.subjects")
+	}
+
+	roleRef, err := rbac.NewRoleRefFromKubeRoleRefV1(kubeRoleBinding.RoleRef)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.roleRef")
+	}
+
+	return &RoleBinding{
+		Name:        kubeRoleBinding.Name,
+		Namespace:   kubeRoleBinding.Namespace,
+		Version:     kubeRoleBinding.APIVersion,
+		Cluster:     kubeRoleBinding.ClusterName,
+		Labels:      kubeRoleBinding.Labels,
+		Annotations: kubeRoleBinding.Annotations,
+		Subjects:    subjects,
+		RoleRef:     *roleRef,
+	}, nil
+}
+
+// fromKubeRoleBindingViaV1 converts a role binding of an older api version,
+// which has the same fields as rbac v1.  The api version of the original
+// is kept.
+func fromKubeRoleBindingViaV1(kubeRoleBinding interface{}) (*RoleBinding, error) {
+	v1RoleBinding := &rbacv1.RoleBinding{}
+	err := converterutils.ConvertKubeObject(kubeRoleBinding, v1RoleBinding)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeRoleBinding, "couldn't convert to %s: %s", rbacv1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeRoleBindingV1(v1RoleBinding)
+}
@@ -0,0 +1,19 @@
+package rolebinding
+
+import (
+	"mantle/pkg/core/rbac"
+)
+
+// RoleBinding defines a role binding object, which grants the rules of a
+// role or cluster role to its subjects within the namespace of the binding
+type RoleBinding struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Subjects []rbac.Subject `json:"subjects,omitempty"`
+	RoleRef  rbac.RoleRef   `json:"roleRef"`
+}
@@ -0,0 +1,61 @@
+package rolebinding
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/koki/json"
+
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRoleBindingRoundTrip(t *testing.T) {
+	kubeRoleBinding := &rbacv1alpha1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1alpha1",
+			Kind:       "RoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "read-pods",
+			Namespace: "testNS",
+		},
+		Subjects: []rbacv1alpha1.Subject{
+			{Kind: "User", APIVersion: "rbac.authorization.k8s.io/v1alpha1", Name: "alice"},
+			{Kind: "ServiceAccount", Name: "builder", Namespace: "ci"},
+		},
+		RoleRef: rbacv1alpha1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "pod-reader",
+		},
+	}
+
+	b, err := NewRoleBindingFromKubeRoleBinding(kubeRoleBinding)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	expected := `"subjects":["user:alice","sa:ci/builder"],"roleRef":"role:pod-reader"`
+	if !strings.Contains(string(data), expected) {
+		t.Errorf("expected the binding to contain %s, got %s", expected, data)
+	}
+
+	b = &RoleBinding{}
+	if err := json.Unmarshal(data, b); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := b.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeRoleBinding) {
+		t.Errorf("round trip changed the role binding\nexpected %#v\ngot      %#v", kubeRoleBinding, obj)
+	}
+}
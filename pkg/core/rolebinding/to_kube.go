@@ -0,0 +1,97 @@
+package rolebinding
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/rbac"
+
+	serrors "github.com/koki/structurederrors"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1alpha1 "k8s.io/api/rbac/v1alpha1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes role binding object of the api version
+// type defined in the object
+func (b *RoleBinding) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(b.Version) {
+	case "rbac.authorization.k8s.io/v1":
+		return b.toKubeV1()
+	case "":
+		return b.toKubeV1()
+	case "rbac.authorization.k8s.io/v1beta1":
+		return b.toKubeV1beta1()
+	case "rbac.authorization.k8s.io/v1alpha1":
+		return b.toKubeV1alpha1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for role binding: %s", b.Version)
+	}
+}
+
+func (b *RoleBinding) toKubeV1() (*rbacv1.RoleBinding, error) {
+	subjects, err := rbac.NewKubeSubjectsV1(b.Subjects)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.subjects")
+	}
+
+	roleRef, err := rbac.NewKubeRoleRefV1(b.RoleRef)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.roleRef")
+	}
+
+	kubeRoleBinding := &rbacv1.RoleBinding{}
+	kubeRoleBinding.Name = b.Name
+	kubeRoleBinding.Namespace = b.Namespace
+	kubeRoleBinding.APIVersion = "rbac.authorization.k8s.io/v1"
+	kubeRoleBinding.ClusterName = b.Cluster
+	kubeRoleBinding.Kind = "RoleBinding"
+	kubeRoleBinding.Labels = b.Labels
+	kubeRoleBinding.Annotations = b.Annotations
+	kubeRoleBinding.Subjects = subjects
+	kubeRoleBinding.RoleRef = roleRef
+
+	return kubeRoleBinding, nil
+}
+
+func (b *RoleBinding) toKubeV1beta1() (*rbacv1beta1.RoleBinding, error) {
+	kubeRoleBinding := &rbacv1beta1.RoleBinding{}
+	err := b.toKubeViaV1(kubeRoleBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeRoleBinding.SetGroupVersionKind(rbacv1beta1.SchemeGroupVersion.WithKind("RoleBinding"))
+	return kubeRoleBinding, nil
+}
+
+func (b *RoleBinding) toKubeV1alpha1() (*rbacv1alpha1.RoleBinding, error) {
+	kubeRoleBinding := &rbacv1alpha1.RoleBinding{}
+	err := b.toKubeViaV1(kubeRoleBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	rbac.SetKubeSubjectsAPIVersionV1alpha1(kubeRoleBinding.Subjects)
+	kubeRoleBinding.SetGroupVersionKind(rbacv1alpha1.SchemeGroupVersion.WithKind("RoleBinding"))
+	return kubeRoleBinding, nil
+}
+
+// toKubeViaV1 converts the role binding to rbac v1 and from there
+// into kubeRoleBinding, a role binding of an older api version
+func (b *RoleBinding) toKubeViaV1(kubeRoleBinding interface{}) error {
+	v1RoleBinding, err := b.toKubeV1()
+	if err != nil {
+		return err
+	}
+
+	err = converterutils.ConvertKubeObject(v1RoleBinding, kubeRoleBinding)
+	if err != nil {
+		return serrors.InvalidInstanceErrorf(b, "couldn't convert to %s: %s", b.Version, err)
+	}
+
+	return nil
+}
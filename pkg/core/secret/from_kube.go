@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+
+	"k8s.io/api/core/v1"
+)
+
+// NewSecretFromKubeSecret will create a new Secret object with the data
+// from a provided kubernetes secret object
+func NewSecretFromKubeSecret(obj interface{}) (*Secret, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.Secret{}):
+		o := obj.(v1.Secret)
+		return fromKubeSecretV1(&o)
+	case reflect.TypeOf(&v1.Secret{}):
+		return fromKubeSecretV1(obj.(*v1.Secret))
+	default:
+		return nil, fmt.Errorf("unknown Secret version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeSecretV1(kubeSecret *v1.Secret) (*Secret, error) {
+	secret := &Secret{
+		Name:        kubeSecret.Name,
+		Namespace:   kubeSecret.Namespace,
+		Version:     kubeSecret.APIVersion,
+		Cluster:     kubeSecret.ClusterName,
+		Labels:      kubeSecret.Labels,
+		Annotations: kubeSecret.Annotations,
+		Type:        fromKubeSecretTypeV1(kubeSecret.Type),
+		StringData:  kubeSecret.StringData,
+	}
+
+	for key, val := range kubeSecret.Data {
+		if utf8.Valid(val) {
+			if secret.Data == nil {
+				secret.Data = map[string]string{}
+			}
+			secret.Data[key] = string(val)
+		} else {
+			if secret.BinaryData == nil {
+				secret.BinaryData = map[string][]byte{}
+			}
+			secret.BinaryData[key] = val
+		}
+	}
+
+	return secret, nil
+}
+
+func fromKubeSecretTypeV1(kubeType v1.SecretType) SecretType {
+	switch kubeType {
+	case v1.SecretTypeOpaque:
+		return SecretTypeOpaque
+	case v1.SecretTypeServiceAccountToken:
+		return SecretTypeServiceAccountToken
+	case v1.SecretTypeDockercfg:
+		return SecretTypeDockercfg
+	case v1.SecretTypeDockerConfigJson:
+		return SecretTypeDockerConfigJSON
+	case v1.SecretTypeBasicAuth:
+		return SecretTypeBasicAuth
+	case v1.SecretTypeSSHAuth:
+		return SecretTypeSSHAuth
+	case v1.SecretTypeTLS:
+		return SecretTypeTLS
+	default:
+		return SecretType(kubeType)
+	}
+}
@@ -0,0 +1,30 @@
+package secret
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "secret"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("Secret"),
+		},
+		New: func() registry.Object {
+			return &Secret{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			s, err := NewSecretFromKubeSecret(obj)
+			if err != nil {
+				return nil, err
+			}
+			return s, nil
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package secret
+
+// Secret defines a secret object.  Values of the kubernetes data field
+// that are valid UTF-8 are shown decoded in Data, and all other values are
+// kept base64 encoded in BinaryData.  StringData is written to kubernetes
+// as it is.
+type Secret struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Type       SecretType        `json:"type,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+	StringData map[string]string `json:"stringData,omitempty"`
+}
+
+// SecretType is one of the built in secret types below.  Any other type
+// is kept as it is, e.g. "example.com/token".
+type SecretType string
+
+const (
+	SecretTypeUnset               SecretType = ""
+	SecretTypeOpaque              SecretType = "opaque"
+	SecretTypeServiceAccountToken SecretType = "service-account-token"
+	SecretTypeDockercfg           SecretType = "dockercfg"
+	SecretTypeDockerConfigJSON    SecretType = "dockerconfigjson"
+	SecretTypeBasicAuth           SecretType = "basic-auth"
+	SecretTypeSSHAuth             SecretType = "ssh-auth"
+	SecretTypeTLS                 SecretType = "tls"
+)
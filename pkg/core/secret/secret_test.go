@@ -0,0 +1,79 @@
+package secret
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretRoundTrip(t *testing.T) {
+	testcases := []struct {
+		description string
+		secretType  v1.SecretType
+	}{
+		{description: "built in type", secretType: v1.SecretTypeTLS},
+		{description: "custom type", secretType: "example.com/token"},
+		{description: "no type"},
+	}
+
+	for _, tc := range testcases {
+		kubeSecret := &v1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "creds",
+				Namespace: "testNS",
+			},
+			Type: tc.secretType,
+			Data: map[string][]byte{
+				"username": []byte("admin"),
+				"key":      {0xff, 0xfe, 0x00, 0x01},
+			},
+			StringData: map[string]string{"password": "hunter2"},
+		}
+
+		s, err := NewSecretFromKubeSecret(kubeSecret)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", tc.description, err)
+		}
+		if s.Data["username"] != "admin" {
+			t.Errorf("%s: expected username to be decoded, got %#v", tc.description, s.Data)
+		}
+		if _, ok := s.BinaryData["key"]; !ok {
+			t.Errorf("%s: expected key to be kept as binary data, got %#v", tc.description, s.BinaryData)
+		}
+
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", tc.description, err)
+		}
+		s = &Secret{}
+		if err := json.Unmarshal(data, s); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", tc.description, data, err)
+		}
+
+		obj, err := s.ToKube()
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", tc.description, err)
+		}
+		if !reflect.DeepEqual(obj, kubeSecret) {
+			t.Errorf("%s: round trip changed the secret\nexpected %#v\ngot      %#v", tc.description, kubeSecret, obj)
+		}
+	}
+}
+
+func TestSecretDuplicateKey(t *testing.T) {
+	s := &Secret{
+		Data:       map[string]string{"key": "text"},
+		BinaryData: map[string][]byte{"key": {0xff}},
+	}
+	if _, err := s.ToKube(); err == nil {
+		t.Errorf("expected a key set in both data and binaryData to be rejected")
+	}
+}
@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes secret object of the api version type
+// defined in the object
+func (s *Secret) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(s.Version) {
+	case "v1":
+		return s.toKubeV1()
+	case "":
+		return s.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for secret: %s", s.Version)
+	}
+}
+
+func (s *Secret) toKubeV1() (*v1.Secret, error) {
+	kubeSecret := &v1.Secret{}
+	kubeSecret.Name = s.Name
+	kubeSecret.Namespace = s.Namespace
+	kubeSecret.APIVersion = "v1"
+	kubeSecret.ClusterName = s.Cluster
+	kubeSecret.Kind = "Secret"
+	kubeSecret.Labels = s.Labels
+	kubeSecret.Annotations = s.Annotations
+	kubeSecret.Type = s.toKubeV1SecretType()
+	kubeSecret.StringData = s.StringData
+
+	if len(s.Data)+len(s.BinaryData) > 0 {
+		kubeSecret.Data = map[string][]byte{}
+	}
+	for key, val := range s.Data {
+		kubeSecret.Data[key] = []byte(val)
+	}
+	for key, val := range s.BinaryData {
+		if _, ok := kubeSecret.Data[key]; ok {
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(key, "key is also set in data"), "$.binaryData.%s", key)
+		}
+		kubeSecret.Data[key] = val
+	}
+
+	return kubeSecret, nil
+}
+
+func (s *Secret) toKubeV1SecretType() v1.SecretType {
+	switch s.Type {
+	case SecretTypeOpaque:
+		return v1.SecretTypeOpaque
+	case SecretTypeServiceAccountToken:
+		return v1.SecretTypeServiceAccountToken
+	case SecretTypeDockercfg:
+		return v1.SecretTypeDockercfg
+	case SecretTypeDockerConfigJSON:
+		return v1.SecretTypeDockerConfigJson
+	case SecretTypeBasicAuth:
+		return v1.SecretTypeBasicAuth
+	case SecretTypeSSHAuth:
+		return v1.SecretTypeSSHAuth
+	case SecretTypeTLS:
+		return v1.SecretTypeTLS
+	default:
+		return v1.SecretType(s.Type)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NewServiceFromKubeService will create a new Service object with the
+// data from a provided kubernetes service object
+func NewServiceFromKubeService(obj interface{}) (*Service, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.Service{}):
+		o := obj.(v1.Service)
+		return fromKubeServiceV1(&o)
+	case reflect.TypeOf(&v1.Service{}):
+		return fromKubeServiceV1(obj.(*v1.Service))
+	default:
+		return nil, fmt.Errorf("unknown Service version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeServiceV1(kubeService *v1.Service) (*Service, error) {
+	serviceType, err := fromKubeServiceTypeV1(kubeService.Spec.Type)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.type")
+	}
+
+	ports, err := fromKubeServicePortsV1(kubeService.Spec.Ports)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.ports")
+	}
+
+	sessionAffinity, err := fromKubeSessionAffinityV1(&kubeService.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.sessionAffinity")
+	}
+
+	externalTrafficPolicy, err := fromKubeExternalTrafficPolicyV1(kubeService.Spec.ExternalTrafficPolicy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.externalTrafficPolicy")
+	}
+
+	service := &Service{
+		Name:                     kubeService.Name,
+		Namespace:                kubeService.Namespace,
+		Version:                  kubeService.APIVersion,
+		Cluster:                  kubeService.ClusterName,
+		Labels:                   kubeService.Labels,
+		Annotations:              kubeService.Annotations,
+		Type:                     serviceType,
+		Ports:                    ports,
+		ClusterIP:                kubeService.Spec.ClusterIP,
+		ExternalIPs:              kubeService.Spec.ExternalIPs,
+		ExternalName:             kubeService.Spec.ExternalName,
+		SessionAffinity:          sessionAffinity,
+		LoadBalancerIP:           kubeService.Spec.LoadBalancerIP,
+		LoadBalancerSourceRanges: kubeService.Spec.LoadBalancerSourceRanges,
+		ExternalTrafficPolicy:    externalTrafficPolicy,
+		HealthCheckNodePort:      kubeService.Spec.HealthCheckNodePort,
+		PublishNotReadyAddresses: kubeService.Spec.PublishNotReadyAddresses,
+	}
+
+	if len(kubeService.Spec.Selector) > 0 {
+		service.Selector = &selector.LabelSelector{
+			MatchLabels: kubeService.Spec.Selector,
+		}
+	}
+
+	return service, nil
+}
+
+func fromKubeServiceTypeV1(kubeType v1.ServiceType) (ServiceType, error) {
+	switch kubeType {
+	case "":
+		return ServiceTypeUnset, nil
+	case v1.ServiceTypeClusterIP:
+		return ServiceTypeClusterIP, nil
+	case v1.ServiceTypeNodePort:
+		return ServiceTypeNodePort, nil
+	case v1.ServiceTypeLoadBalancer:
+		return ServiceTypeLoadBalancer, nil
+	case v1.ServiceTypeExternalName:
+		return ServiceTypeExternalName, nil
+	default:
+		return ServiceTypeUnset, serrors.InvalidValueErrorf(kubeType, "unrecognized service type")
+	}
+}
+
+func fromKubeServicePortsV1(kubePorts []v1.ServicePort) ([]Port, error) {
+	if len(kubePorts) == 0 {
+		return nil, nil
+	}
+
+	ports := make([]Port, len(kubePorts))
+	for i, kubePort := range kubePorts {
+		port := Port{
+			Name:     kubePort.Name,
+			Port:     kubePort.Port,
+			NodePort: kubePort.NodePort,
+		}
+
+		if kubePort.TargetPort != (intstr.IntOrString{}) {
+			targetPort := kubePort.TargetPort
+			port.TargetPort = &targetPort
+		}
+
+		switch kubePort.Protocol {
+		case "":
+			port.Protocol = pod.ProtocolUnset
+		case v1.ProtocolTCP:
+			port.Protocol = pod.ProtocolTCP
+		case v1.ProtocolUDP:
+			port.Protocol = pod.ProtocolUDP
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(kubePort.Protocol, "unrecognized protocol"), "$.%d", i)
+		}
+
+		ports[i] = port
+	}
+
+	return ports, nil
+}
+
+func fromKubeSessionAffinityV1(kubeSpec *v1.ServiceSpec) (*SessionAffinity, error) {
+	affinity := &SessionAffinity{}
+	switch kubeSpec.SessionAffinity {
+	case "":
+		if kubeSpec.SessionAffinityConfig != nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeSpec.SessionAffinityConfig, "sessionAffinityConfig needs sessionAffinity %s", v1.ServiceAffinityClientIP)
+		}
+		return nil, nil
+	case v1.ServiceAffinityNone:
+		affinity.Type = SessionAffinityNone
+		if kubeSpec.SessionAffinityConfig != nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeSpec.SessionAffinityConfig, "sessionAffinityConfig needs sessionAffinity %s", v1.ServiceAffinityClientIP)
+		}
+	case v1.ServiceAffinityClientIP:
+		affinity.Type = SessionAffinityClientIP
+		if kubeSpec.SessionAffinityConfig != nil && kubeSpec.SessionAffinityConfig.ClientIP != nil {
+			affinity.Timeout = kubeSpec.SessionAffinityConfig.ClientIP.TimeoutSeconds
+		}
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeSpec.SessionAffinity, "unrecognized session affinity")
+	}
+
+	return affinity, nil
+}
+
+func fromKubeExternalTrafficPolicyV1(kubePolicy v1.ServiceExternalTrafficPolicyType) (ExternalTrafficPolicy, error) {
+	switch kubePolicy {
+	case "":
+		return ExternalTrafficPolicyUnset, nil
+	case v1.ServiceExternalTrafficPolicyTypeLocal:
+		return ExternalTrafficPolicyLocal, nil
+	case v1.ServiceExternalTrafficPolicyTypeCluster:
+		return ExternalTrafficPolicyCluster, nil
+	default:
+		return ExternalTrafficPolicyUnset, serrors.InvalidValueErrorf(kubePolicy, "unrecognized external traffic policy")
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"mantle/internal/marshal"
+	"mantle/pkg/core/pod"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Port is a port exposed by a service.  It is written as
+// "[name:]port[:nodePort][->targetPort][/protocol]", e.g.
+// "http:80->8080/tcp".  The target port can be the name of a container
+// port.
+type Port struct {
+	Name       string
+	Port       int32
+	NodePort   int32
+	TargetPort *intstr.IntOrString
+	Protocol   pod.Protocol
+}
+
+var (
+	portRegexp     = regexp.MustCompile(`^(?:([a-z0-9-]*[a-z][a-z0-9-]*):)?([0-9]+)(?::([0-9]+))?(?:->([0-9]+|[a-z0-9-]*[a-z][a-z0-9-]*))?(?:/([a-z]+))?$`)
+	portNameRegexp = regexp.MustCompile(`^[a-z0-9-]*[a-z][a-z0-9-]*$`)
+)
+
+func (p *Port) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a port string")
+	}
+
+	return p.Unmarshal(str)
+}
+
+func (p *Port) Unmarshal(str string) error {
+	matches := portRegexp.FindStringSubmatch(str)
+	if len(matches) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected [name:]port[:nodePort][->targetPort][/protocol]")
+	}
+
+	p.Name = matches[1]
+	port, err := marshal.ParsePort(matches[2])
+	if err != nil {
+		return err
+	}
+	p.Port = port
+
+	if len(matches[3]) > 0 {
+		port, err := marshal.ParsePort(matches[3])
+		if err != nil {
+			return err
+		}
+		p.NodePort = port
+	}
+
+	if len(matches[4]) > 0 {
+		targetPort := intstr.Parse(matches[4])
+		if targetPort.Type == intstr.Int && (targetPort.IntVal <= 0 || targetPort.IntVal > 65535) {
+			return serrors.InvalidValueErrorf(matches[4], "expected a port number")
+		}
+		p.TargetPort = &targetPort
+	}
+
+	switch protocol := pod.Protocol(matches[5]); protocol {
+	case pod.ProtocolUnset, pod.ProtocolTCP, pod.ProtocolUDP:
+		p.Protocol = protocol
+	default:
+		return serrors.InvalidValueErrorf(matches[5], "unsupported protocol, expected %s or %s", pod.ProtocolTCP, pod.ProtocolUDP)
+	}
+
+	return nil
+}
+
+func (p Port) MarshalJSON() ([]byte, error) {
+	if len(p.Name) > 0 && !portNameRegexp.MatchString(p.Name) {
+		return nil, serrors.InvalidValueErrorf(p.Name, "port names need at least one lowercase letter")
+	}
+
+	return json.Marshal(p.String())
+}
+
+func (p Port) String() string {
+	str := strconv.Itoa(int(p.Port))
+	if len(p.Name) > 0 {
+		str = fmt.Sprintf("%s:%s", p.Name, str)
+	}
+	if p.NodePort != 0 {
+		str = fmt.Sprintf("%s:%d", str, p.NodePort)
+	}
+	if p.TargetPort != nil {
+		str = fmt.Sprintf("%s->%s", str, p.TargetPort.String())
+	}
+	if len(p.Protocol) > 0 {
+		str = fmt.Sprintf("%s/%s", str, p.Protocol)
+	}
+
+	return str
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"mantle/internal/pkg/core/selector"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// Service defines a service object.  The status of the service is
+// dropped.
+type Service struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Type  ServiceType `json:"type,omitempty"`
+	Ports []Port      `json:"ports,omitempty"`
+	// Selector can only match labels exactly, e.g. "app=web,tier=db"
+	Selector                 *selector.LabelSelector `json:"selector,omitempty"`
+	ClusterIP                string                  `json:"clusterIP,omitempty"`
+	ExternalIPs              []string                `json:"externalIPs,omitempty"`
+	ExternalName             string                  `json:"externalName,omitempty"`
+	SessionAffinity          *SessionAffinity        `json:"sessionAffinity,omitempty"`
+	LoadBalancerIP           string                  `json:"loadBalancerIP,omitempty"`
+	LoadBalancerSourceRanges []string                `json:"loadBalancerSourceRanges,omitempty"`
+	ExternalTrafficPolicy    ExternalTrafficPolicy   `json:"externalTrafficPolicy,omitempty"`
+	HealthCheckNodePort      int32                   `json:"healthCheckNodePort,omitempty"`
+	PublishNotReadyAddresses bool                    `json:"publishNotReadyAddresses,omitempty"`
+}
+
+type ServiceType string
+
+const (
+	ServiceTypeUnset        ServiceType = ""
+	ServiceTypeClusterIP    ServiceType = "cluster-ip"
+	ServiceTypeNodePort     ServiceType = "node-port"
+	ServiceTypeLoadBalancer ServiceType = "load-balancer"
+	ServiceTypeExternalName ServiceType = "external-name"
+)
+
+type ExternalTrafficPolicy string
+
+const (
+	ExternalTrafficPolicyUnset   ExternalTrafficPolicy = ""
+	ExternalTrafficPolicyLocal   ExternalTrafficPolicy = "local"
+	ExternalTrafficPolicyCluster ExternalTrafficPolicy = "cluster"
+)
+
+type SessionAffinityType string
+
+const (
+	SessionAffinityNone     SessionAffinityType = "none"
+	SessionAffinityClientIP SessionAffinityType = "client-ip"
+)
+
+// SessionAffinity is written as "none", "client-ip" or
+// "client-ip:timeoutSeconds", e.g. "client-ip:10800"
+type SessionAffinity struct {
+	Type    SessionAffinityType
+	Timeout *int32
+}
+
+func (a *SessionAffinity) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form %s or %s:timeout", SessionAffinityNone, SessionAffinityClientIP)
+	}
+
+	segments := strings.Split(str, ":")
+	a.Type = SessionAffinityType(segments[0])
+	switch a.Type {
+	case SessionAffinityNone:
+		if len(segments) > 1 {
+			return serrors.InvalidValueErrorf(str, "%s doesn't take a timeout", SessionAffinityNone)
+		}
+	case SessionAffinityClientIP:
+		if len(segments) > 2 {
+			return serrors.InvalidValueErrorf(str, "expected %s:timeout", SessionAffinityClientIP)
+		}
+		if len(segments) > 1 {
+			timeout, err := strconv.ParseInt(segments[1], 10, 32)
+			if err != nil {
+				return serrors.InvalidValueErrorf(str, "timeout should be a number of seconds")
+			}
+			t := int32(timeout)
+			a.Timeout = &t
+		}
+	default:
+		return serrors.InvalidValueErrorf(str, "unsupported session affinity, expected %s or %s", SessionAffinityNone, SessionAffinityClientIP)
+	}
+
+	return nil
+}
+
+func (a SessionAffinity) MarshalJSON() ([]byte, error) {
+	if a.Timeout == nil {
+		return json.Marshal(a.Type)
+	}
+
+	return json.Marshal(string(a.Type) + ":" + strconv.Itoa(int(*a.Timeout)))
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestServiceRoundTrip(t *testing.T) {
+	kubeService := &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "testNS",
+		},
+		Spec: v1.ServiceSpec{
+			Type: v1.ServiceTypeLoadBalancer,
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080), Protocol: v1.ProtocolTCP},
+				{Name: "https", Port: 443, NodePort: 30443, TargetPort: intstr.FromString("https")},
+				{Port: 53, Protocol: v1.ProtocolUDP},
+			},
+			Selector:                 map[string]string{"app": "web"},
+			ExternalIPs:              []string{"192.0.2.10"},
+			SessionAffinity:          v1.ServiceAffinityClientIP,
+			SessionAffinityConfig:    &v1.SessionAffinityConfig{ClientIP: &v1.ClientIPConfig{TimeoutSeconds: int32Ptr(600)}},
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			ExternalTrafficPolicy:    v1.ServiceExternalTrafficPolicyTypeLocal,
+		},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{{IP: "192.0.2.1"}},
+			},
+		},
+	}
+
+	s, err := NewServiceFromKubeService(kubeService)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	s = &Service{}
+	if err := json.Unmarshal(data, s); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := s.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+
+	kubeService.Status = v1.ServiceStatus{}
+	if !reflect.DeepEqual(obj, kubeService) {
+		t.Errorf("round trip changed the service\nexpected %#v\ngot      %#v", kubeService, obj)
+	}
+}
+
+func TestPortShorthand(t *testing.T) {
+	testcases := []struct {
+		str      string
+		expected Port
+		pass     bool
+	}{
+		{
+			str:      "80",
+			expected: Port{Port: 80},
+			pass:     true,
+		},
+		{
+			str:      "http:80->8080/tcp",
+			expected: Port{Name: "http", Port: 80, TargetPort: &intstr.IntOrString{Type: intstr.Int, IntVal: 8080}, Protocol: "tcp"},
+			pass:     true,
+		},
+		{
+			str:      "https:443:30443->https",
+			expected: Port{Name: "https", Port: 443, NodePort: 30443, TargetPort: &intstr.IntOrString{Type: intstr.String, StrVal: "https"}},
+			pass:     true,
+		},
+		{str: "http", pass: false},
+		{str: "80->0", pass: false},
+		{str: "80/sctp", pass: false},
+		{str: "70000", pass: false},
+	}
+
+	for _, tc := range testcases {
+		port := Port{}
+		err := json.Unmarshal([]byte(`"`+tc.str+`"`), &port)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+			continue
+		}
+		if !tc.pass {
+			continue
+		}
+		if !reflect.DeepEqual(port, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.str, tc.expected, port)
+		}
+		if port.String() != tc.str {
+			t.Errorf("%s: marshalled as %s", tc.str, port.String())
+		}
+	}
+}
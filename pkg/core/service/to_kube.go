@@ -0,0 +1,169 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes service object of the api version
+// type defined in the object
+func (s *Service) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(s.Version) {
+	case "v1":
+		return s.toKubeV1()
+	case "":
+		return s.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for service: %s", s.Version)
+	}
+}
+
+func (s *Service) toKubeV1() (*v1.Service, error) {
+	serviceType, err := s.toKubeV1ServiceType()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.type")
+	}
+
+	ports, err := s.toKubeV1ServicePorts()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.ports")
+	}
+
+	externalTrafficPolicy, err := s.toKubeV1ExternalTrafficPolicy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.externalTrafficPolicy")
+	}
+
+	kubeService := &v1.Service{}
+	kubeService.Name = s.Name
+	kubeService.Namespace = s.Namespace
+	kubeService.APIVersion = "v1"
+	kubeService.ClusterName = s.Cluster
+	kubeService.Kind = "Service"
+	kubeService.Labels = s.Labels
+	kubeService.Annotations = s.Annotations
+	kubeService.Spec = v1.ServiceSpec{
+		Type:                     serviceType,
+		Ports:                    ports,
+		ClusterIP:                s.ClusterIP,
+		ExternalIPs:              s.ExternalIPs,
+		ExternalName:             s.ExternalName,
+		LoadBalancerIP:           s.LoadBalancerIP,
+		LoadBalancerSourceRanges: s.LoadBalancerSourceRanges,
+		ExternalTrafficPolicy:    externalTrafficPolicy,
+		HealthCheckNodePort:      s.HealthCheckNodePort,
+		PublishNotReadyAddresses: s.PublishNotReadyAddresses,
+	}
+
+	if s.Selector != nil {
+		if len(s.Selector.MatchExpressions) > 0 {
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidInstanceErrorf(s.Selector, "services only support selecting exact labels"), "$.selector")
+		}
+		kubeService.Spec.Selector = s.Selector.MatchLabels
+	}
+
+	err = s.toKubeV1SessionAffinity(&kubeService.Spec)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.sessionAffinity")
+	}
+
+	return kubeService, nil
+}
+
+func (s *Service) toKubeV1ServiceType() (v1.ServiceType, error) {
+	switch s.Type {
+	case ServiceTypeUnset:
+		return "", nil
+	case ServiceTypeClusterIP:
+		return v1.ServiceTypeClusterIP, nil
+	case ServiceTypeNodePort:
+		return v1.ServiceTypeNodePort, nil
+	case ServiceTypeLoadBalancer:
+		return v1.ServiceTypeLoadBalancer, nil
+	case ServiceTypeExternalName:
+		return v1.ServiceTypeExternalName, nil
+	default:
+		return "", serrors.InvalidValueErrorf(s.Type, "unrecognized service type")
+	}
+}
+
+func (s *Service) toKubeV1ServicePorts() ([]v1.ServicePort, error) {
+	if len(s.Ports) == 0 {
+		return nil, nil
+	}
+
+	kubePorts := make([]v1.ServicePort, len(s.Ports))
+	for i, port := range s.Ports {
+		kubePort := v1.ServicePort{
+			Name:     port.Name,
+			Port:     port.Port,
+			NodePort: port.NodePort,
+		}
+
+		if port.TargetPort != nil {
+			kubePort.TargetPort = *port.TargetPort
+		}
+
+		switch port.Protocol {
+		case pod.ProtocolUnset:
+			kubePort.Protocol = ""
+		case pod.ProtocolTCP:
+			kubePort.Protocol = v1.ProtocolTCP
+		case pod.ProtocolUDP:
+			kubePort.Protocol = v1.ProtocolUDP
+		default:
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(port.Protocol, "unrecognized protocol"), "$.%d", i)
+		}
+
+		kubePorts[i] = kubePort
+	}
+
+	return kubePorts, nil
+}
+
+func (s *Service) toKubeV1SessionAffinity(kubeSpec *v1.ServiceSpec) error {
+	if s.SessionAffinity == nil {
+		return nil
+	}
+
+	switch s.SessionAffinity.Type {
+	case SessionAffinityNone:
+		if s.SessionAffinity.Timeout != nil {
+			return serrors.InvalidInstanceErrorf(s.SessionAffinity, "%s doesn't take a timeout", SessionAffinityNone)
+		}
+		kubeSpec.SessionAffinity = v1.ServiceAffinityNone
+	case SessionAffinityClientIP:
+		kubeSpec.SessionAffinity = v1.ServiceAffinityClientIP
+		if s.SessionAffinity.Timeout != nil {
+			kubeSpec.SessionAffinityConfig = &v1.SessionAffinityConfig{
+				ClientIP: &v1.ClientIPConfig{
+					TimeoutSeconds: s.SessionAffinity.Timeout,
+				},
+			}
+		}
+	default:
+		return serrors.InvalidValueErrorf(s.SessionAffinity.Type, "unrecognized session affinity")
+	}
+
+	return nil
+}
+
+func (s *Service) toKubeV1ExternalTrafficPolicy() (v1.ServiceExternalTrafficPolicyType, error) {
+	switch s.ExternalTrafficPolicy {
+	case ExternalTrafficPolicyUnset:
+		return "", nil
+	case ExternalTrafficPolicyLocal:
+		return v1.ServiceExternalTrafficPolicyTypeLocal, nil
+	case ExternalTrafficPolicyCluster:
+		return v1.ServiceExternalTrafficPolicyTypeCluster, nil
+	default:
+		return "", serrors.InvalidValueErrorf(s.ExternalTrafficPolicy, "unrecognized external traffic policy")
+	}
+}
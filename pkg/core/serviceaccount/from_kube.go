@@ -0,0 +1,50 @@
+package serviceaccount
+
+import (
+	"fmt"
+	"reflect"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+)
+
+// NewServiceAccountFromKubeServiceAccount will create a new ServiceAccount
+// object with the data from a provided kubernetes service account object
+func NewServiceAccountFromKubeServiceAccount(obj interface{}) (*ServiceAccount, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.ServiceAccount{}):
+		o := obj.(v1.ServiceAccount)
+		return fromKubeServiceAccountV1(&o)
+	case reflect.TypeOf(&v1.ServiceAccount{}):
+		return fromKubeServiceAccountV1(obj.(*v1.ServiceAccount))
+	default:
+		return nil, fmt.Errorf("unknown ServiceAccount version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeServiceAccountV1(kubeServiceAccount *v1.ServiceAccount) (*ServiceAccount, error) {
+	serviceAccount := &ServiceAccount{
+		Name:             kubeServiceAccount.Name,
+		Namespace:        kubeServiceAccount.Namespace,
+		Version:          kubeServiceAccount.APIVersion,
+		Cluster:          kubeServiceAccount.ClusterName,
+		Labels:           kubeServiceAccount.Labels,
+		Annotations:      kubeServiceAccount.Annotations,
+		AutomountSAToken: kubeServiceAccount.AutomountServiceAccountToken,
+	}
+
+	for i, secret := range kubeServiceAccount.Secrets {
+		if secret != (v1.ObjectReference{Name: secret.Name}) {
+			err := serrors.InvalidInstanceErrorf(secret, "only the name of the secret can be set")
+			return nil, serrors.ContextualizeErrorf(err, "$.secrets.%d", i)
+		}
+		serviceAccount.Secrets = append(serviceAccount.Secrets, secret.Name)
+	}
+
+	for _, secret := range kubeServiceAccount.ImagePullSecrets {
+		serviceAccount.ImagePullSecrets = append(serviceAccount.ImagePullSecrets, secret.Name)
+	}
+
+	return serviceAccount, nil
+}
@@ -0,0 +1,30 @@
+package serviceaccount
+
+import (
+	"mantle/pkg/registry"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "service_account"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			v1.SchemeGroupVersion.WithKind("ServiceAccount"),
+		},
+		New: func() registry.Object {
+			return &ServiceAccount{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			s, err := NewServiceAccountFromKubeServiceAccount(obj)
+			if err != nil {
+				return nil, err
+			}
+			return s, nil
+		},
+	})
+}
@@ -0,0 +1,16 @@
+package serviceaccount
+
+// ServiceAccount defines a service account object.  Secrets and image
+// pull secrets are referenced by name.
+type ServiceAccount struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Secrets          []string `json:"secrets,omitempty"`
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+	AutomountSAToken *bool    `json:"automountServiceAccountToken,omitempty"`
+}
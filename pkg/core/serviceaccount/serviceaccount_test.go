@@ -0,0 +1,59 @@
+package serviceaccount
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceAccountRoundTrip(t *testing.T) {
+	automount := false
+	kubeServiceAccount := &v1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "builder",
+			Namespace: "ci",
+		},
+		Secrets:                      []v1.ObjectReference{{Name: "builder-token"}},
+		ImagePullSecrets:             []v1.LocalObjectReference{{Name: "registry"}},
+		AutomountServiceAccountToken: &automount,
+	}
+
+	s, err := NewServiceAccountFromKubeServiceAccount(kubeServiceAccount)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	s = &ServiceAccount{}
+	if err := json.Unmarshal(data, s); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := s.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, kubeServiceAccount) {
+		t.Errorf("round trip changed the service account\nexpected %#v\ngot      %#v", kubeServiceAccount, obj)
+	}
+}
+
+func TestServiceAccountSecretReference(t *testing.T) {
+	kubeServiceAccount := &v1.ServiceAccount{
+		Secrets: []v1.ObjectReference{{Name: "token", Namespace: "other"}},
+	}
+	if _, err := NewServiceAccountFromKubeServiceAccount(kubeServiceAccount); err == nil {
+		t.Errorf("expected a secret reference with a namespace to be rejected")
+	}
+}
@@ -0,0 +1,43 @@
+package serviceaccount
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes service account object of the api
+// version type defined in the object
+func (s *ServiceAccount) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(s.Version) {
+	case "v1":
+		return s.toKubeV1()
+	case "":
+		return s.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for service account: %s", s.Version)
+	}
+}
+
+func (s *ServiceAccount) toKubeV1() (*v1.ServiceAccount, error) {
+	kubeServiceAccount := &v1.ServiceAccount{}
+	kubeServiceAccount.Name = s.Name
+	kubeServiceAccount.Namespace = s.Namespace
+	kubeServiceAccount.APIVersion = "v1"
+	kubeServiceAccount.ClusterName = s.Cluster
+	kubeServiceAccount.Kind = "ServiceAccount"
+	kubeServiceAccount.Labels = s.Labels
+	kubeServiceAccount.Annotations = s.Annotations
+	kubeServiceAccount.AutomountServiceAccountToken = s.AutomountSAToken
+
+	for _, secret := range s.Secrets {
+		kubeServiceAccount.Secrets = append(kubeServiceAccount.Secrets, v1.ObjectReference{Name: secret})
+	}
+	for _, secret := range s.ImagePullSecrets {
+		kubeServiceAccount.ImagePullSecrets = append(kubeServiceAccount.ImagePullSecrets, v1.LocalObjectReference{Name: secret})
+	}
+
+	return kubeServiceAccount, nil
+}
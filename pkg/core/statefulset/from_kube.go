@@ -0,0 +1,152 @@
+package statefulset
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pvc"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+)
+
+// NewStatefulSetFromKubeStatefulSet will create a new StatefulSet object
+// with the data from a provided kubernetes stateful set object of any
+// supported api version
+func NewStatefulSetFromKubeStatefulSet(obj interface{}) (*StatefulSet, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(appsv1.StatefulSet{}):
+		o := obj.(appsv1.StatefulSet)
+		return fromKubeStatefulSetV1(&o)
+	case reflect.TypeOf(&appsv1.StatefulSet{}):
+		return fromKubeStatefulSetV1(obj.(*appsv1.StatefulSet))
+	case reflect.TypeOf(appsv1beta1.StatefulSet{}):
+		o := obj.(appsv1beta1.StatefulSet)
+		return fromKubeStatefulSetViaV1(&o)
+	case reflect.TypeOf(&appsv1beta1.StatefulSet{}):
+		return fromKubeStatefulSetViaV1(obj.(*appsv1beta1.StatefulSet))
+	case reflect.TypeOf(appsv1beta2.StatefulSet{}):
+		o := obj.(appsv1beta2.StatefulSet)
+		return fromKubeStatefulSetViaV1(&o)
+	case reflect.TypeOf(&appsv1beta2.StatefulSet{}):
+		return fromKubeStatefulSetViaV1(obj.(*appsv1beta2.StatefulSet))
+	default:
+		return nil, fmt.Errorf("unknown StatefulSet version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeStatefulSetV1(kubeStatefulSet *appsv1.StatefulSet) (*StatefulSet, error) {
+	template, err := pod.NewPodTemplateFromKubePodTemplateSpec(&kubeStatefulSet.Spec.Template)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.template")
+	}
+
+	podManagement, err := fromKubePodManagementPolicyV1(kubeStatefulSet.Spec.PodManagementPolicy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.podManagementPolicy")
+	}
+
+	updateStrategy, err := fromKubeUpdateStrategyV1(&kubeStatefulSet.Spec.UpdateStrategy)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.updateStrategy")
+	}
+
+	volumeClaims, err := fromKubeVolumeClaimTemplatesV1(kubeStatefulSet.Spec.VolumeClaimTemplates)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.spec.volumeClaimTemplates")
+	}
+
+	return &StatefulSet{
+		Name:                 kubeStatefulSet.Name,
+		Namespace:            kubeStatefulSet.Namespace,
+		Version:              kubeStatefulSet.APIVersion,
+		Cluster:              kubeStatefulSet.ClusterName,
+		Labels:               kubeStatefulSet.Labels,
+		Annotations:          kubeStatefulSet.Annotations,
+		Replicas:             kubeStatefulSet.Spec.Replicas,
+		Selector:             selector.NewLabelSelectorFromKubeLabelSelectorV1(kubeStatefulSet.Spec.Selector),
+		Service:              kubeStatefulSet.Spec.ServiceName,
+		PodManagement:        podManagement,
+		UpdateStrategy:       updateStrategy,
+		RevisionHistoryLimit: kubeStatefulSet.Spec.RevisionHistoryLimit,
+		VolumeClaims:         volumeClaims,
+		Template:             *template,
+	}, nil
+}
+
+// fromKubeStatefulSetViaV1 converts a stateful set of an older api
+// version, which has the same fields as apps/v1.  The api version of the
+// original is kept.
+func fromKubeStatefulSetViaV1(kubeStatefulSet interface{}) (*StatefulSet, error) {
+	v1StatefulSet := &appsv1.StatefulSet{}
+	err := converterutils.ConvertKubeObject(kubeStatefulSet, v1StatefulSet)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeStatefulSet, "couldn't convert to %s: %s", appsv1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeStatefulSetV1(v1StatefulSet)
+}
+
+func fromKubePodManagementPolicyV1(kubePolicy appsv1.PodManagementPolicyType) (PodManagementPolicy, error) {
+	switch kubePolicy {
+	case "":
+		return PodManagementUnset, nil
+	case appsv1.OrderedReadyPodManagement:
+		return PodManagementOrderedReady, nil
+	case appsv1.ParallelPodManagement:
+		return PodManagementParallel, nil
+	default:
+		return PodManagementUnset, serrors.InvalidValueErrorf(kubePolicy, "unrecognized pod management policy")
+	}
+}
+
+func fromKubeUpdateStrategyV1(kubeStrategy *appsv1.StatefulSetUpdateStrategy) (*UpdateStrategy, error) {
+	if len(kubeStrategy.Type) == 0 && kubeStrategy.RollingUpdate == nil {
+		return nil, nil
+	}
+
+	strategy := &UpdateStrategy{}
+	switch kubeStrategy.Type {
+	case appsv1.OnDeleteStatefulSetStrategyType:
+		strategy.Type = UpdateStrategyTypeOnDelete
+		if kubeStrategy.RollingUpdate != nil {
+			return nil, serrors.InvalidInstanceErrorf(kubeStrategy, "rollingUpdate can't be set for the %s strategy", appsv1.OnDeleteStatefulSetStrategyType)
+		}
+	case appsv1.RollingUpdateStatefulSetStrategyType, "":
+		strategy.Type = UpdateStrategyTypeRolling
+		if kubeStrategy.RollingUpdate != nil {
+			strategy.Partition = kubeStrategy.RollingUpdate.Partition
+		}
+	default:
+		return nil, serrors.InvalidValueErrorf(kubeStrategy.Type, "unrecognized stateful set update strategy")
+	}
+
+	return strategy, nil
+}
+
+func fromKubeVolumeClaimTemplatesV1(kubeClaims []v1.PersistentVolumeClaim) (pvc.ClaimTemplates, error) {
+	var claims pvc.ClaimTemplates
+	names := map[string]bool{}
+	for i := range kubeClaims {
+		kubeClaim := &kubeClaims[i]
+		if names[kubeClaim.Name] {
+			return nil, serrors.ContextualizeErrorf(serrors.InvalidValueErrorf(kubeClaim.Name, "duplicate volume claim template name"), "$.%d", i)
+		}
+		names[kubeClaim.Name] = true
+
+		claim, err := pvc.NewClaimTemplateFromKubePersistentVolumeClaim(kubeClaim)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.%d", i)
+		}
+		claims = append(claims, *claim)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,34 @@
+package statefulset
+
+import (
+	"mantle/pkg/registry"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "stateful_set"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			appsv1.SchemeGroupVersion.WithKind("StatefulSet"),
+			appsv1beta1.SchemeGroupVersion.WithKind("StatefulSet"),
+			appsv1beta2.SchemeGroupVersion.WithKind("StatefulSet"),
+		},
+		New: func() registry.Object {
+			return &StatefulSet{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			s, err := NewStatefulSetFromKubeStatefulSet(obj)
+			if err != nil {
+				return nil, err
+			}
+			return s, nil
+		},
+	})
+}
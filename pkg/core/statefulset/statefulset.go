@@ -0,0 +1,97 @@
+package statefulset
+
+import (
+	"strconv"
+	"strings"
+
+	"mantle/internal/pkg/core/selector"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pvc"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// StatefulSet defines a stateful set object
+type StatefulSet struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Replicas             *int32                  `json:"replicas,omitempty"`
+	Selector             *selector.LabelSelector `json:"selector,omitempty"`
+	Service              string                  `json:"service,omitempty"`
+	PodManagement        PodManagementPolicy     `json:"podManagement,omitempty"`
+	UpdateStrategy       *UpdateStrategy         `json:"updateStrategy,omitempty"`
+	RevisionHistoryLimit *int32                  `json:"revisionHistoryLimit,omitempty"`
+	VolumeClaims         pvc.ClaimTemplates      `json:"volumeClaimTemplates,omitempty"`
+
+	Template pod.PodTemplate `json:"template"`
+}
+
+type PodManagementPolicy string
+
+const (
+	PodManagementUnset        PodManagementPolicy = ""
+	PodManagementOrderedReady PodManagementPolicy = "ordered-ready"
+	PodManagementParallel     PodManagementPolicy = "parallel"
+)
+
+type UpdateStrategyType string
+
+const (
+	UpdateStrategyTypeOnDelete UpdateStrategyType = "on-delete"
+	UpdateStrategyTypeRolling  UpdateStrategyType = "rolling"
+)
+
+// UpdateStrategy is how a stateful set replaces its pods.  It is written
+// as "on-delete", "rolling" or "rolling:partition", where only pods with
+// an ordinal of at least partition are updated.
+type UpdateStrategy struct {
+	Type      UpdateStrategyType
+	Partition *int32
+}
+
+func (s *UpdateStrategy) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return serrors.InvalidValueErrorf(string(data), "expected a string of the form %s or %s:partition", UpdateStrategyTypeOnDelete, UpdateStrategyTypeRolling)
+	}
+
+	segments := strings.Split(str, ":")
+	s.Type = UpdateStrategyType(segments[0])
+	switch s.Type {
+	case UpdateStrategyTypeOnDelete:
+		if len(segments) > 1 {
+			return serrors.InvalidValueErrorf(str, "%s doesn't take any parameters", UpdateStrategyTypeOnDelete)
+		}
+	case UpdateStrategyTypeRolling:
+		if len(segments) > 2 {
+			return serrors.InvalidValueErrorf(str, "expected %s:partition", UpdateStrategyTypeRolling)
+		}
+		if len(segments) > 1 {
+			partition, err := strconv.ParseInt(segments[1], 10, 32)
+			if err != nil {
+				return serrors.InvalidValueErrorf(str, "partition should be an integer")
+			}
+			p := int32(partition)
+			s.Partition = &p
+		}
+	default:
+		return serrors.InvalidValueErrorf(str, "unsupported update strategy, expected %s or %s", UpdateStrategyTypeOnDelete, UpdateStrategyTypeRolling)
+	}
+
+	return nil
+}
+
+func (s UpdateStrategy) MarshalJSON() ([]byte, error) {
+	if s.Partition == nil {
+		return json.Marshal(s.Type)
+	}
+
+	return json.Marshal(string(s.Type) + ":" + strconv.Itoa(int(*s.Partition)))
+}
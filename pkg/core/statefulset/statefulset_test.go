@@ -0,0 +1,193 @@
+package statefulset
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/internal/converterutils"
+
+	"github.com/koki/json"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func testKubeStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka",
+			Namespace: "testNS",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: int32Ptr(3),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "kafka"},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "kafka"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "kafka", Image: "kafka"}},
+				},
+			},
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec: v1.PersistentVolumeClaimSpec{
+						AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+						StorageClassName: stringPtr("fast"),
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "logs",
+						Labels: map[string]string{"app": "kafka"},
+					},
+					Spec: v1.PersistentVolumeClaimSpec{
+						AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce, v1.ReadOnlyMany},
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+						},
+					},
+				},
+			},
+			ServiceName:         "kafka-headless",
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: int32Ptr(2),
+				},
+			},
+			RevisionHistoryLimit: int32Ptr(10),
+		},
+	}
+}
+
+func TestStatefulSetRoundTrip(t *testing.T) {
+	testcases := []struct {
+		version string
+		obj     runtime.Object
+	}{
+		{
+			version: "apps/v1",
+			obj:     &appsv1.StatefulSet{},
+		},
+		{
+			version: "apps/v1beta1",
+			obj:     &appsv1beta1.StatefulSet{},
+		},
+		{
+			version: "apps/v1beta2",
+			obj:     &appsv1beta2.StatefulSet{},
+		},
+	}
+
+	for _, tc := range testcases {
+		err := converterutils.ConvertKubeObject(testKubeStatefulSet(), tc.obj)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.version, err)
+		}
+		tc.obj.GetObjectKind().SetGroupVersionKind(schema.FromAPIVersionAndKind(tc.version, "StatefulSet"))
+
+		s, err := NewStatefulSetFromKubeStatefulSet(tc.obj)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", tc.version, err)
+		}
+		if s.Version != tc.version {
+			t.Errorf("%s: wrong version %s", tc.version, s.Version)
+		}
+
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", tc.version, err)
+		}
+		s = &StatefulSet{}
+		if err := json.Unmarshal(data, s); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", tc.version, data, err)
+		}
+
+		obj, err := s.ToKube()
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", tc.version, err)
+		}
+		if !reflect.DeepEqual(obj, tc.obj) {
+			t.Errorf("%s: round trip changed the stateful set\nexpected %#v\ngot      %#v", tc.version, tc.obj, obj)
+		}
+	}
+}
+
+func TestUpdateStrategyShorthand(t *testing.T) {
+	testcases := []struct {
+		str  string
+		pass bool
+	}{
+		{str: "on-delete", pass: true},
+		{str: "rolling", pass: true},
+		{str: "rolling:2", pass: true},
+		{str: "on-delete:2", pass: false},
+		{str: "rolling:two", pass: false},
+		{str: "rolling:1:2", pass: false},
+		{str: "recreate", pass: false},
+	}
+
+	for _, tc := range testcases {
+		strategy := UpdateStrategy{}
+		err := json.Unmarshal([]byte(`"`+tc.str+`"`), &strategy)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected result %v", tc.str, err)
+		}
+	}
+}
+
+func TestStatefulSetKeepsClaimOrder(t *testing.T) {
+	kubeStatefulSet := testKubeStatefulSet()
+	// volumeClaimTemplates can't be changed once the stateful set exists,
+	// so claims that aren't sorted by name must keep their order
+	kubeStatefulSet.Spec.VolumeClaimTemplates[1].Name = "conf"
+
+	s, err := NewStatefulSetFromKubeStatefulSet(kubeStatefulSet)
+	if err != nil {
+		t.Fatalf("conversion from kube failed: %v", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	s = &StatefulSet{}
+	if err := json.Unmarshal(data, s); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+
+	obj, err := s.ToKube()
+	if err != nil {
+		t.Fatalf("conversion to kube failed: %v", err)
+	}
+	claims := obj.(*appsv1.StatefulSet).Spec.VolumeClaimTemplates
+	if len(claims) != 2 || claims[0].Name != "data" || claims[1].Name != "conf" {
+		t.Errorf("expected claims data and conf in order, got %#v", claims)
+	}
+}
@@ -0,0 +1,168 @@
+package statefulset
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes stateful set object of the api version
+// type defined in the object
+func (s *StatefulSet) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(s.Version) {
+	case "apps/v1":
+		return s.toKubeV1()
+	case "":
+		return s.toKubeV1()
+	case "apps/v1beta1":
+		return s.toKubeV1beta1()
+	case "apps/v1beta2":
+		return s.toKubeV1beta2()
+	default:
+		return nil, fmt.Errorf("unsupported api version for stateful set: %s", s.Version)
+	}
+}
+
+func (s *StatefulSet) toKubeV1() (*appsv1.StatefulSet, error) {
+	template, err := s.Template.ToKube("v1")
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.template")
+	}
+
+	podManagement, err := s.toKubeV1PodManagementPolicy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.podManagement")
+	}
+
+	updateStrategy, err := s.toKubeV1UpdateStrategy()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.updateStrategy")
+	}
+
+	volumeClaims, err := s.toKubeV1VolumeClaimTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeStatefulSet := &appsv1.StatefulSet{}
+	kubeStatefulSet.Name = s.Name
+	kubeStatefulSet.Namespace = s.Namespace
+	kubeStatefulSet.APIVersion = "apps/v1"
+	kubeStatefulSet.ClusterName = s.Cluster
+	kubeStatefulSet.Kind = "StatefulSet"
+	kubeStatefulSet.Labels = s.Labels
+	kubeStatefulSet.Annotations = s.Annotations
+	kubeStatefulSet.Spec = appsv1.StatefulSetSpec{
+		Replicas:             s.Replicas,
+		Selector:             s.Selector.ToKubeLabelSelectorV1(),
+		Template:             *template.(*v1.PodTemplateSpec),
+		VolumeClaimTemplates: volumeClaims,
+		ServiceName:          s.Service,
+		PodManagementPolicy:  podManagement,
+		UpdateStrategy:       updateStrategy,
+		RevisionHistoryLimit: s.RevisionHistoryLimit,
+	}
+
+	return kubeStatefulSet, nil
+}
+
+func (s *StatefulSet) toKubeV1beta1() (*appsv1beta1.StatefulSet, error) {
+	kubeStatefulSet := &appsv1beta1.StatefulSet{}
+	err := s.toKubeViaV1(kubeStatefulSet)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeStatefulSet.SetGroupVersionKind(appsv1beta1.SchemeGroupVersion.WithKind("StatefulSet"))
+	return kubeStatefulSet, nil
+}
+
+func (s *StatefulSet) toKubeV1beta2() (*appsv1beta2.StatefulSet, error) {
+	kubeStatefulSet := &appsv1beta2.StatefulSet{}
+	err := s.toKubeViaV1(kubeStatefulSet)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeStatefulSet.SetGroupVersionKind(appsv1beta2.SchemeGroupVersion.WithKind("StatefulSet"))
+	return kubeStatefulSet, nil
+}
+
+// toKubeViaV1 converts the stateful set to apps/v1 and from there into
+// kubeStatefulSet, a stateful set of an older api version
+func (s *StatefulSet) toKubeViaV1(kubeStatefulSet interface{}) error {
+	v1StatefulSet, err := s.toKubeV1()
+	if err != nil {
+		return err
+	}
+
+	err = converterutils.ConvertKubeObject(v1StatefulSet, kubeStatefulSet)
+	if err != nil {
+		return serrors.InvalidInstanceErrorf(s, "couldn't convert to %s: %s", s.Version, err)
+	}
+
+	return nil
+}
+
+func (s *StatefulSet) toKubeV1PodManagementPolicy() (appsv1.PodManagementPolicyType, error) {
+	switch s.PodManagement {
+	case PodManagementUnset:
+		return "", nil
+	case PodManagementOrderedReady:
+		return appsv1.OrderedReadyPodManagement, nil
+	case PodManagementParallel:
+		return appsv1.ParallelPodManagement, nil
+	default:
+		return "", serrors.InvalidValueErrorf(s.PodManagement, "unrecognized pod management policy")
+	}
+}
+
+func (s *StatefulSet) toKubeV1UpdateStrategy() (appsv1.StatefulSetUpdateStrategy, error) {
+	if s.UpdateStrategy == nil {
+		return appsv1.StatefulSetUpdateStrategy{}, nil
+	}
+
+	switch s.UpdateStrategy.Type {
+	case UpdateStrategyTypeOnDelete:
+		if s.UpdateStrategy.Partition != nil {
+			return appsv1.StatefulSetUpdateStrategy{}, serrors.InvalidInstanceErrorf(s.UpdateStrategy, "the %s strategy doesn't take a partition", UpdateStrategyTypeOnDelete)
+		}
+		return appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.OnDeleteStatefulSetStrategyType,
+		}, nil
+	case UpdateStrategyTypeRolling:
+		strategy := appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		}
+		if s.UpdateStrategy.Partition != nil {
+			strategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: s.UpdateStrategy.Partition,
+			}
+		}
+		return strategy, nil
+	default:
+		return appsv1.StatefulSetUpdateStrategy{}, serrors.InvalidValueErrorf(s.UpdateStrategy.Type, "unrecognized stateful set update strategy")
+	}
+}
+
+func (s *StatefulSet) toKubeV1VolumeClaimTemplates() ([]v1.PersistentVolumeClaim, error) {
+	var kubeClaims []v1.PersistentVolumeClaim
+	for i := range s.VolumeClaims {
+		kubeClaim, err := s.VolumeClaims[i].ToKube("v1")
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.volumeClaimTemplates.%d", i)
+		}
+		kubeClaims = append(kubeClaims, *kubeClaim.(*v1.PersistentVolumeClaim))
+	}
+
+	return kubeClaims, nil
+}
@@ -0,0 +1,93 @@
+package storageclass
+
+import (
+	"fmt"
+	"reflect"
+
+	"mantle/internal/converterutils"
+	"mantle/pkg/core/persistentvolume"
+
+	serrors "github.com/koki/structurederrors"
+
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+)
+
+// NewStorageClassFromKubeStorageClass will create a new StorageClass
+// object with the data from a provided kubernetes storage class object of
+// any supported api version
+func NewStorageClassFromKubeStorageClass(obj interface{}) (*StorageClass, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(storagev1.StorageClass{}):
+		o := obj.(storagev1.StorageClass)
+		return fromKubeStorageClassV1(&o)
+	case reflect.TypeOf(&storagev1.StorageClass{}):
+		return fromKubeStorageClassV1(obj.(*storagev1.StorageClass))
+	case reflect.TypeOf(storagev1beta1.StorageClass{}):
+		o := obj.(storagev1beta1.StorageClass)
+		return fromKubeStorageClassViaV1(&o)
+	case reflect.TypeOf(&storagev1beta1.StorageClass{}):
+		return fromKubeStorageClassViaV1(obj.(*storagev1beta1.StorageClass))
+	default:
+		return nil, fmt.Errorf("unknown StorageClass version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeStorageClassV1(kubeStorageClass *storagev1.StorageClass) (*StorageClass, error) {
+	var reclaimPolicy persistentvolume.ReclaimPolicy
+	if kubeStorageClass.ReclaimPolicy != nil {
+		var err error
+		reclaimPolicy, err = persistentvolume.NewReclaimPolicyFromKubeReclaimPolicy(*kubeStorageClass.ReclaimPolicy)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "$.reclaimPolicy")
+		}
+	}
+
+	bindingMode, err := fromKubeVolumeBindingModeV1(kubeStorageClass.VolumeBindingMode)
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.volumeBindingMode")
+	}
+
+	return &StorageClass{
+		Name:                 kubeStorageClass.Name,
+		Namespace:            kubeStorageClass.Namespace,
+		Version:              kubeStorageClass.APIVersion,
+		Cluster:              kubeStorageClass.ClusterName,
+		Labels:               kubeStorageClass.Labels,
+		Annotations:          kubeStorageClass.Annotations,
+		Provisioner:          kubeStorageClass.Provisioner,
+		Parameters:           kubeStorageClass.Parameters,
+		ReclaimPolicy:        reclaimPolicy,
+		MountOptions:         kubeStorageClass.MountOptions,
+		AllowVolumeExpansion: kubeStorageClass.AllowVolumeExpansion,
+		VolumeBindingMode:    bindingMode,
+	}, nil
+}
+
+// fromKubeStorageClassViaV1 converts a storage class of an older api
+// version, which has the same fields as storage/v1.  The api version of
+// the original is kept.
+func fromKubeStorageClassViaV1(kubeStorageClass interface{}) (*StorageClass, error) {
+	v1StorageClass := &storagev1.StorageClass{}
+	err := converterutils.ConvertKubeObject(kubeStorageClass, v1StorageClass)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(kubeStorageClass, "couldn't convert to %s: %s", storagev1.SchemeGroupVersion, err)
+	}
+
+	return fromKubeStorageClassV1(v1StorageClass)
+}
+
+func fromKubeVolumeBindingModeV1(kubeMode *storagev1.VolumeBindingMode) (VolumeBindingMode, error) {
+	if kubeMode == nil {
+		return VolumeBindingModeUnset, nil
+	}
+
+	switch *kubeMode {
+	case storagev1.VolumeBindingImmediate:
+		return VolumeBindingModeImmediate, nil
+	case storagev1.VolumeBindingWaitForFirstConsumer:
+		return VolumeBindingModeWaitForFirstConsumer, nil
+	default:
+		return VolumeBindingModeUnset, serrors.InvalidValueErrorf(*kubeMode, "unrecognized volume binding mode")
+	}
+}
@@ -0,0 +1,32 @@
+package storageclass
+
+import (
+	"mantle/pkg/registry"
+
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const MantleType = "storage_class"
+
+func init() {
+	registry.Register(registry.Converter{
+		Name: MantleType,
+		Kinds: []schema.GroupVersionKind{
+			storagev1.SchemeGroupVersion.WithKind("StorageClass"),
+			storagev1beta1.SchemeGroupVersion.WithKind("StorageClass"),
+		},
+		New: func() registry.Object {
+			return &StorageClass{}
+		},
+		FromKube: func(obj runtime.Object) (registry.Object, error) {
+			s, err := NewStorageClassFromKubeStorageClass(obj)
+			if err != nil {
+				return nil, err
+			}
+			return s, nil
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package storageclass
+
+import (
+	"mantle/pkg/core/persistentvolume"
+)
+
+// StorageClass defines a storage class object.  The pinned kubernetes api
+// has no allowed topologies, provisioning can only be restricted to a
+// topology by the volume binding mode.
+type StorageClass struct {
+	Version     string            `json:"version,omitempty"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Provisioner          string                         `json:"provisioner"`
+	Parameters           map[string]string              `json:"parameters,omitempty"`
+	ReclaimPolicy        persistentvolume.ReclaimPolicy `json:"reclaimPolicy,omitempty"`
+	MountOptions         []string                       `json:"mountOptions,omitempty"`
+	AllowVolumeExpansion *bool                          `json:"allowVolumeExpansion,omitempty"`
+	VolumeBindingMode    VolumeBindingMode              `json:"volumeBindingMode,omitempty"`
+}
+
+type VolumeBindingMode string
+
+const (
+	VolumeBindingModeUnset                VolumeBindingMode = ""
+	VolumeBindingModeImmediate            VolumeBindingMode = "immediate"
+	VolumeBindingModeWaitForFirstConsumer VolumeBindingMode = "wait-for-first-consumer"
+)
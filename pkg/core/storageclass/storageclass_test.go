@@ -0,0 +1,87 @@
+package storageclass
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestStorageClassRoundTrip(t *testing.T) {
+	reclaimPolicy := v1.PersistentVolumeReclaimRetain
+	bindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+	allowExpansion := true
+	kubeStorageClass := &storagev1.StorageClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "storage.k8s.io/v1",
+			Kind:       "StorageClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fast",
+		},
+		Provisioner:          "kubernetes.io/gce-pd",
+		Parameters:           map[string]string{"type": "pd-ssd"},
+		ReclaimPolicy:        &reclaimPolicy,
+		MountOptions:         []string{"debug"},
+		AllowVolumeExpansion: &allowExpansion,
+		VolumeBindingMode:    &bindingMode,
+	}
+
+	v1beta1Reclaim := v1.PersistentVolumeReclaimRetain
+	v1beta1Binding := storagev1beta1.VolumeBindingWaitForFirstConsumer
+	kubeBetaStorageClass := &storagev1beta1.StorageClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "storage.k8s.io/v1beta1",
+			Kind:       "StorageClass",
+		},
+		ObjectMeta:           kubeStorageClass.ObjectMeta,
+		Provisioner:          kubeStorageClass.Provisioner,
+		Parameters:           kubeStorageClass.Parameters,
+		ReclaimPolicy:        &v1beta1Reclaim,
+		MountOptions:         kubeStorageClass.MountOptions,
+		AllowVolumeExpansion: &allowExpansion,
+		VolumeBindingMode:    &v1beta1Binding,
+	}
+
+	for _, kubeObj := range []runtime.Object{kubeStorageClass, kubeBetaStorageClass} {
+		version := kubeObj.GetObjectKind().GroupVersionKind().Version
+		s, err := NewStorageClassFromKubeStorageClass(kubeObj)
+		if err != nil {
+			t.Fatalf("%s: conversion from kube failed: %v", version, err)
+		}
+
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", version, err)
+		}
+		s = &StorageClass{}
+		if err := json.Unmarshal(data, s); err != nil {
+			t.Fatalf("%s: unmarshal of %s failed: %v", version, data, err)
+		}
+
+		obj, err := s.ToKube()
+		if err != nil {
+			t.Fatalf("%s: conversion to kube failed: %v", version, err)
+		}
+		if !reflect.DeepEqual(obj, kubeObj) {
+			t.Errorf("%s: round trip changed the storage class\nexpected %#v\ngot      %#v", version, kubeObj, obj)
+		}
+	}
+}
+
+func TestStorageClassBindingMode(t *testing.T) {
+	s := &StorageClass{}
+	err := json.Unmarshal([]byte(`{"provisioner":"example.com/nfs","volumeBindingMode":"lazy"}`), s)
+	if err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if _, err := s.ToKube(); err == nil {
+		t.Errorf("expected an unknown volume binding mode to be rejected")
+	}
+}
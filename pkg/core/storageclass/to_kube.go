@@ -0,0 +1,92 @@
+package storageclass
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/converterutils"
+
+	serrors "github.com/koki/structurederrors"
+
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube will return a kubernetes storage class object of the api version
+// type defined in the object
+func (s *StorageClass) ToKube() (runtime.Object, error) {
+	switch strings.ToLower(s.Version) {
+	case "storage.k8s.io/v1":
+		return s.toKubeV1()
+	case "":
+		return s.toKubeV1()
+	case "storage.k8s.io/v1beta1":
+		return s.toKubeV1beta1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for storage class: %s", s.Version)
+	}
+}
+
+func (s *StorageClass) toKubeV1() (*storagev1.StorageClass, error) {
+	reclaimPolicy, err := s.ReclaimPolicy.ToKube()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.reclaimPolicy")
+	}
+
+	bindingMode, err := s.toKubeV1VolumeBindingMode()
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "$.volumeBindingMode")
+	}
+
+	kubeStorageClass := &storagev1.StorageClass{}
+	kubeStorageClass.Name = s.Name
+	kubeStorageClass.Namespace = s.Namespace
+	kubeStorageClass.APIVersion = "storage.k8s.io/v1"
+	kubeStorageClass.ClusterName = s.Cluster
+	kubeStorageClass.Kind = "StorageClass"
+	kubeStorageClass.Labels = s.Labels
+	kubeStorageClass.Annotations = s.Annotations
+	kubeStorageClass.Provisioner = s.Provisioner
+	kubeStorageClass.Parameters = s.Parameters
+	kubeStorageClass.MountOptions = s.MountOptions
+	kubeStorageClass.AllowVolumeExpansion = s.AllowVolumeExpansion
+	kubeStorageClass.VolumeBindingMode = bindingMode
+	if len(reclaimPolicy) > 0 {
+		kubeStorageClass.ReclaimPolicy = &reclaimPolicy
+	}
+
+	return kubeStorageClass, nil
+}
+
+func (s *StorageClass) toKubeV1beta1() (*storagev1beta1.StorageClass, error) {
+	v1StorageClass, err := s.toKubeV1()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeStorageClass := &storagev1beta1.StorageClass{}
+	err = converterutils.ConvertKubeObject(v1StorageClass, kubeStorageClass)
+	if err != nil {
+		return nil, serrors.InvalidInstanceErrorf(s, "couldn't convert to %s: %s", s.Version, err)
+	}
+
+	kubeStorageClass.SetGroupVersionKind(storagev1beta1.SchemeGroupVersion.WithKind("StorageClass"))
+	return kubeStorageClass, nil
+}
+
+func (s *StorageClass) toKubeV1VolumeBindingMode() (*storagev1.VolumeBindingMode, error) {
+	var mode storagev1.VolumeBindingMode
+	switch s.VolumeBindingMode {
+	case VolumeBindingModeUnset:
+		return nil, nil
+	case VolumeBindingModeImmediate:
+		mode = storagev1.VolumeBindingImmediate
+	case VolumeBindingModeWaitForFirstConsumer:
+		mode = storagev1.VolumeBindingWaitForFirstConsumer
+	default:
+		return nil, serrors.InvalidValueErrorf(s.VolumeBindingMode, "unrecognized volume binding mode")
+	}
+
+	return &mode, nil
+}
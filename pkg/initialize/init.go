@@ -7,8 +7,20 @@ import (
 	"mantle/pkg/codec"
 )
 
-func MantleInit() error {
-	out, err := codec.Decode(os.Stdin)
+// MantleInit converts the documents in the file named by opts.Filename, or
+// on stdin when no file is given, and writes the result to stdout
+func MantleInit(opts codec.Options) error {
+	input := os.Stdin
+	if len(opts.Filename) > 0 {
+		f, err := os.Open(opts.Filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	out, err := codec.Decode(input, opts)
 	if err != nil {
 		return err
 	}
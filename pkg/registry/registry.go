@@ -0,0 +1,101 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Object is a mantle resource that can be converted into a kubernetes
+// object
+type Object interface {
+	ToKube() (runtime.Object, error)
+}
+
+// NewFunc returns an empty mantle object that a mantle document can be
+// unmarshalled into
+type NewFunc func() Object
+
+// FromKubeFunc creates a mantle object from a typed kubernetes object
+type FromKubeFunc func(obj runtime.Object) (Object, error)
+
+// Converter converts one resource type between its kubernetes and mantle
+// representations
+type Converter struct {
+	// Name is the key that holds the resource in a mantle document,
+	// e.g. "config_map"
+	Name string
+
+	// Kinds lists every kubernetes kind that FromKube accepts
+	Kinds []schema.GroupVersionKind
+
+	New      NewFunc
+	FromKube FromKubeFunc
+}
+
+var (
+	lock    sync.RWMutex
+	byName  = map[string]*Converter{}
+	byKinds = map[schema.GroupVersionKind]*Converter{}
+)
+
+// Register makes a converter available to the codec.  It is meant to be
+// called from the init function of the package that defines the mantle
+// type, and panics if the converter is incomplete or if its name or one
+// of its kinds is already registered.
+func Register(c Converter) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if len(c.Name) == 0 || c.New == nil || c.FromKube == nil {
+		panic(fmt.Sprintf("registry: incomplete converter %q", c.Name))
+	}
+	if _, ok := byName[c.Name]; ok {
+		panic(fmt.Sprintf("registry: converter %q registered twice", c.Name))
+	}
+	for _, gvk := range c.Kinds {
+		if existing, ok := byKinds[gvk]; ok {
+			panic(fmt.Sprintf("registry: %s already converted by %q", gvk, existing.Name))
+		}
+	}
+
+	converter := &c
+	byName[c.Name] = converter
+	for _, gvk := range c.Kinds {
+		byKinds[gvk] = converter
+	}
+}
+
+// ForName returns the converter for a mantle document key
+func ForName(name string) (*Converter, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	c, ok := byName[name]
+	return c, ok
+}
+
+// ForKind returns the converter for a kubernetes kind
+func ForKind(gvk schema.GroupVersionKind) (*Converter, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	c, ok := byKinds[gvk]
+	return c, ok
+}
+
+// Names returns the sorted names of all registered converters
+func Names() []string {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type testObject struct{}
+
+func (o *testObject) ToKube() (runtime.Object, error) {
+	return nil, nil
+}
+
+func newTestConverter(name string, kinds ...schema.GroupVersionKind) Converter {
+	return Converter{
+		Name:  name,
+		Kinds: kinds,
+		New: func() Object {
+			return &testObject{}
+		},
+		FromKube: func(obj runtime.Object) (Object, error) {
+			return &testObject{}, nil
+		},
+	}
+}
+
+func TestRegister(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "test.mantle", Version: "v1", Kind: "Widget"}
+	Register(newTestConverter("widget", gvk))
+
+	c, ok := ForName("widget")
+	if !ok || c.Name != "widget" {
+		t.Errorf("converter not found by name, got %v", c)
+	}
+
+	c, ok = ForKind(gvk)
+	if !ok || c.Name != "widget" {
+		t.Errorf("converter not found by kind, got %v", c)
+	}
+
+	if _, ok := ForKind(schema.GroupVersionKind{Group: "test.mantle", Version: "v2", Kind: "Widget"}); ok {
+		t.Errorf("converter found for unregistered kind")
+	}
+}
+
+func TestRegisterConflicts(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "test.mantle", Version: "v1", Kind: "Gadget"}
+	Register(newTestConverter("gadget", gvk))
+
+	testcases := []struct {
+		description string
+		converter   Converter
+	}{
+		{
+			description: "duplicate name",
+			converter:   newTestConverter("gadget"),
+		},
+		{
+			description: "duplicate kind",
+			converter:   newTestConverter("other_gadget", gvk),
+		},
+		{
+			description: "missing name",
+			converter:   newTestConverter(""),
+		},
+	}
+
+	for _, tc := range testcases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected Register to panic", tc.description)
+				}
+			}()
+			Register(tc.converter)
+		}()
+	}
+}